@@ -0,0 +1,220 @@
+package gorm_seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrDependencyNotFound is returned when a container has no matching dependency
+	ErrDependencyNotFound = errors.New("dependency not found")
+	// ErrAmbiguousDependency is returned when several dependencies match an unnamed lookup
+	ErrAmbiguousDependency = errors.New("ambiguous dependency")
+)
+
+// ContainerSeeder is a seeder that resolves its dependencies from a typed
+// Container instead of a map. The db passed to SeedContainer is bound to ctx.
+type ContainerSeeder interface {
+	Seeder
+	// SeedContainer executes the seeding logic
+	SeedContainer(ctx context.Context, db *gorm.DB, c *Container) error
+}
+
+// containerKey identifies a dependency by type and optional name
+type containerKey struct {
+	typ  reflect.Type
+	name string
+}
+
+// containerEntry is a dependency stored in a Container
+type containerEntry struct {
+	key   containerKey
+	value interface{}
+}
+
+// Container holds typed dependencies for seeders, keyed by type and an
+// optional name. It is safe for concurrent use.
+type Container struct {
+	mu      sync.RWMutex
+	entries []containerEntry
+	index   map[containerKey]int
+}
+
+// NewContainer creates an empty dependency container
+func NewContainer() *Container {
+	return &Container{
+		index: make(map[containerKey]int),
+	}
+}
+
+// ContainerFromMap creates a container from a map of dependencies. Each value
+// is stored under its key as name and its dynamic type, so
+// deps["enforcer"] can be resolved with Resolve[*casbin.Enforcer] or
+// ResolveNamed[*casbin.Enforcer](c, "enforcer").
+func ContainerFromMap(deps map[string]interface{}) *Container {
+	c := NewContainer()
+
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := deps[name]
+		if value == nil {
+			continue
+		}
+		c.set(containerKey{typ: reflect.TypeOf(value), name: name}, value)
+	}
+	return c
+}
+
+// Provide stores an unnamed dependency of type T, replacing any previous one
+func Provide[T any](c *Container, value T) {
+	ProvideNamed(c, "", value)
+}
+
+// ProvideNamed stores a dependency of type T under name, replacing any previous one
+func ProvideNamed[T any](c *Container, name string, value T) {
+	c.set(containerKey{typ: typeOf[T](), name: name}, value)
+}
+
+// Resolve returns the dependency of type T. When no unnamed dependency of
+// type T was provided, it falls back to the single dependency of any name
+// whose value is a T.
+func Resolve[T any](c *Container) (T, error) {
+	value, err := c.resolve(typeOf[T](), "", false)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	// A nil interface value is stored untyped
+	typed, _ := value.(T)
+	return typed, nil
+}
+
+// ResolveNamed returns the dependency of type T provided under name
+func ResolveNamed[T any](c *Container, name string) (T, error) {
+	value, err := c.resolve(typeOf[T](), name, true)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	// A nil interface value is stored untyped
+	typed, _ := value.(T)
+	return typed, nil
+}
+
+// MustResolve is like Resolve but panics when the dependency is missing
+func MustResolve[T any](c *Container) T {
+	value, err := Resolve[T](c)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Map returns the named dependencies as a map, for seeders that take
+// map[string]interface{}
+func (c *Container) Map() map[string]interface{} {
+	deps := make(map[string]interface{})
+	if c == nil {
+		return deps
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, entry := range c.entries {
+		if entry.key.name != "" {
+			deps[entry.key.name] = entry.value
+		}
+	}
+	return deps
+}
+
+// typeOf returns the reflect.Type of T, including interface types
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// set stores a value under key
+func (c *Container) set(key containerKey, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if i, ok := c.index[key]; ok {
+		c.entries[i].value = value
+		return
+	}
+	c.index[key] = len(c.entries)
+	c.entries = append(c.entries, containerEntry{key: key, value: value})
+}
+
+// resolve looks up a value of type typ. An exact match on type and name wins;
+// otherwise the single value assignable to typ is used, considering only
+// values with the same name when named is true.
+func (c *Container) resolve(typ reflect.Type, name string, named bool) (interface{}, error) {
+	if c == nil {
+		return nil, fmt.Errorf("%w: %s", ErrDependencyNotFound, describeDependency(typ, name))
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if i, ok := c.index[containerKey{typ: typ, name: name}]; ok {
+		return c.entries[i].value, nil
+	}
+
+	var matches []containerEntry
+	for _, entry := range c.entries {
+		if named && entry.key.name != name {
+			continue
+		}
+		if valueType := reflect.TypeOf(entry.value); valueType != nil && valueType.AssignableTo(typ) {
+			matches = append(matches, entry)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrDependencyNotFound, describeDependency(typ, name))
+	case 1:
+		return matches[0].value, nil
+	default:
+		names := make([]string, len(matches))
+		for i, match := range matches {
+			names[i] = fmt.Sprintf("%q", match.key.name)
+		}
+		return nil, fmt.Errorf("%w: %s matches dependencies named %s, use ResolveNamed",
+			ErrAmbiguousDependency, describeDependency(typ, name), strings.Join(names, ", "))
+	}
+}
+
+// describeDependency formats a dependency key for error messages
+func describeDependency(typ reflect.Type, name string) string {
+	if name == "" {
+		return typ.String()
+	}
+	return fmt.Sprintf("%s named %q", typ, name)
+}
+
+// bridgeDependencies makes the run's dependencies available to both kinds of
+// seeders: ContainerSeeders receive opts.Container and map-based seeders
+// receive deps. When only one of them is given, the other is derived from it.
+func bridgeDependencies(deps map[string]interface{}, opts RunOptions) (map[string]interface{}, RunOptions) {
+	if opts.Container == nil {
+		opts.Container = ContainerFromMap(deps)
+	} else if deps == nil {
+		deps = opts.Container.Map()
+	}
+	return deps, opts
+}
@@ -0,0 +1,204 @@
+package gorm_seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// testMailer is a dependency used by container tests
+type testMailer struct {
+	from string
+}
+
+func (m *testMailer) String() string {
+	return m.from
+}
+
+// mockContainerSeeder is a test seeder that implements ContainerSeeder
+type mockContainerSeeder struct {
+	mockSeeder
+	seedContainerFunc func(ctx context.Context, db *gorm.DB, c *Container) error
+}
+
+func (m *mockContainerSeeder) SeedContainer(ctx context.Context, db *gorm.DB, c *Container) error {
+	return m.seedContainerFunc(ctx, db, c)
+}
+
+func TestContainer_ProvideResolve(t *testing.T) {
+	c := NewContainer()
+	Provide(c, &testMailer{from: "default"})
+	ProvideNamed(c, "billing", &testMailer{from: "billing"})
+	Provide(c, 42)
+
+	mailer, err := Resolve[*testMailer](c)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if mailer.from != "default" {
+		t.Errorf("expected default mailer, got '%s'", mailer.from)
+	}
+
+	billing, err := ResolveNamed[*testMailer](c, "billing")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if billing.from != "billing" {
+		t.Errorf("expected billing mailer, got '%s'", billing.from)
+	}
+
+	if n := MustResolve[int](c); n != 42 {
+		t.Errorf("expected 42, got %d", n)
+	}
+}
+
+func TestContainer_Missing(t *testing.T) {
+	c := NewContainer()
+
+	_, err := Resolve[*testMailer](c)
+	if !errors.Is(err, ErrDependencyNotFound) {
+		t.Fatalf("expected ErrDependencyNotFound, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "*gorm_seed.testMailer") {
+		t.Errorf("expected error to name the missing type, got: %v", err)
+	}
+
+	_, err = ResolveNamed[*testMailer](c, "billing")
+	if err == nil || !strings.Contains(err.Error(), `named "billing"`) {
+		t.Errorf("expected error to name the missing dependency, got: %v", err)
+	}
+
+	var nilContainer *Container
+	if _, err := Resolve[int](nilContainer); !errors.Is(err, ErrDependencyNotFound) {
+		t.Errorf("expected ErrDependencyNotFound from nil container, got: %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustResolve to panic")
+		}
+	}()
+	MustResolve[string](c)
+}
+
+func TestContainer_ResolveByAssignableType(t *testing.T) {
+	c := NewContainer()
+	ProvideNamed(c, "mailer", &testMailer{from: "only"})
+
+	stringer, err := Resolve[fmt.Stringer](c)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if stringer.String() != "only" {
+		t.Errorf("expected mailer to be resolved as fmt.Stringer, got '%s'", stringer)
+	}
+
+	ProvideNamed(c, "other", &testMailer{from: "other"})
+	if _, err := Resolve[*testMailer](c); !errors.Is(err, ErrAmbiguousDependency) {
+		t.Errorf("expected ErrAmbiguousDependency, got: %v", err)
+	}
+}
+
+func TestContainerFromMap(t *testing.T) {
+	c := ContainerFromMap(map[string]interface{}{
+		"mailer": &testMailer{from: "map"},
+		"limit":  10,
+		"empty":  nil,
+	})
+
+	mailer, err := Resolve[*testMailer](c)
+	if err != nil || mailer.from != "map" {
+		t.Errorf("expected mailer from map, got %v (err: %v)", mailer, err)
+	}
+
+	limit, err := ResolveNamed[int](c, "limit")
+	if err != nil || limit != 10 {
+		t.Errorf("expected limit 10, got %d (err: %v)", limit, err)
+	}
+
+	deps := c.Map()
+	if len(deps) != 2 || deps["limit"] != 10 {
+		t.Errorf("expected map round trip, got %v", deps)
+	}
+}
+
+func TestRunAll_ContainerSeederWithMapDeps(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	var received *testMailer
+	Register(&mockContainerSeeder{
+		mockSeeder: mockSeeder{name: "001_container"},
+		seedContainerFunc: func(ctx context.Context, db *gorm.DB, c *Container) error {
+			var err error
+			received, err = Resolve[*testMailer](c)
+			return err
+		},
+	})
+
+	err := RunAll(db, map[string]interface{}{"mailer": &testMailer{from: "map"}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if received == nil || received.from != "map" {
+		t.Errorf("expected seeder to resolve mailer from deps map, got %v", received)
+	}
+}
+
+func TestRunAllWithOptions_Container(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	c := NewContainer()
+	ProvideNamed(c, "mailer", &testMailer{from: "container"})
+
+	var typed *testMailer
+	var legacy interface{}
+
+	Register(&mockContainerSeeder{
+		mockSeeder: mockSeeder{name: "001_container"},
+		seedContainerFunc: func(ctx context.Context, db *gorm.DB, c *Container) error {
+			var err error
+			typed, err = ResolveNamed[*testMailer](c, "mailer")
+			return err
+		},
+	})
+	Register(&mockSeeder{
+		name: "002_legacy",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			legacy = deps["mailer"]
+			return nil
+		},
+	})
+	Register(&mockContainerSeeder{
+		mockSeeder: mockSeeder{name: "003_missing"},
+		seedContainerFunc: func(ctx context.Context, db *gorm.DB, c *Container) error {
+			_, err := Resolve[int](c)
+			return err
+		},
+	})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		ContinueOnError: true,
+		Container:       c,
+	})
+
+	if typed == nil || typed.from != "container" {
+		t.Errorf("expected container seeder to resolve mailer, got %v", typed)
+	}
+	if mailer, ok := legacy.(*testMailer); !ok || mailer.from != "container" {
+		t.Errorf("expected map-based seeder to receive the named dependency, got %v", legacy)
+	}
+
+	var seederErr *SeederError
+	if !errors.As(err, &seederErr) || seederErr.SeederName != "003_missing" {
+		t.Fatalf("expected SeederError from 003_missing, got %v", err)
+	}
+	if !errors.Is(err, ErrDependencyNotFound) {
+		t.Errorf("expected ErrDependencyNotFound, got: %v", err)
+	}
+}
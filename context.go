@@ -0,0 +1,88 @@
+package gorm_seed
+
+import (
+	"context"
+
+	"github.com/lunar-kiln/gorm-seed/fake"
+	"gorm.io/gorm"
+)
+
+// ContextSeeder is a seeder that receives the context of the run.
+// The db passed to SeedContext is already bound to ctx via db.WithContext.
+type ContextSeeder interface {
+	Seeder
+	// SeedContext executes the seeding logic, stopping when ctx is done
+	SeedContext(ctx context.Context, db *gorm.DB, deps map[string]interface{}) error
+}
+
+// contextSeederAdapter runs a plain Seeder as a ContextSeeder
+type contextSeederAdapter struct {
+	Seeder
+}
+
+func (a contextSeederAdapter) SeedContext(ctx context.Context, db *gorm.DB, deps map[string]interface{}) error {
+	return a.Seed(db, deps)
+}
+
+// AsContextSeeder adapts a Seeder so it can be run with a context. Plain
+// seeders only observe cancellation through the queries they issue on db.
+func AsContextSeeder(seeder Seeder) ContextSeeder {
+	if contextSeeder, ok := seeder.(ContextSeeder); ok {
+		return contextSeeder
+	}
+	return contextSeederAdapter{seeder}
+}
+
+// seederStats is what runSeeder observed while a seeder ran
+type seederStats struct {
+	rows       map[string]int64
+	statements []string
+}
+
+// runSeeder seeds or unseeds a single seeder on its database with the
+// context, per-seeder timeout and transaction mode from opts. A panic of the
+// seeder is returned as a *PanicError.
+func runSeeder(ctx context.Context, seeder Seeder, db *gorm.DB, deps map[string]interface{}, opts RunOptions, dir direction) (seederStats, error) {
+	if opts.SeederTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.SeederTimeout)
+		defer cancel()
+	}
+
+	counter := &rowCounter{rows: make(map[string]int64)}
+	ctx = withRowCounter(ctx, counter)
+	ctx = withFaker(ctx, fake.New(seederRandomSeed(opts.RandomSeed, seeder.Name())))
+
+	run := recoverPanic(func(tx *gorm.DB) error {
+		if dir == directionUnseed {
+			return unseed(seeder, tx, deps)
+		}
+		if containerSeeder, ok := seeder.(ContainerSeeder); ok {
+			return containerSeeder.SeedContainer(ctx, tx, opts.Container)
+		}
+		return AsContextSeeder(seeder).SeedContext(ctx, tx, deps)
+	})
+
+	db = databaseFor(seeder, db, opts)
+
+	var stats seederStats
+	var err error
+	if opts.DryRun {
+		stats.statements, err = dryRun(db.WithContext(ctx), run)
+	} else {
+		err = withTransaction(seeder, db.WithContext(ctx), opts, run)
+	}
+	stats.rows = counter.snapshot()
+	return stats, err
+}
+
+// RunAllContext executes all registered seeders in dependency order, stopping
+// when ctx is cancelled
+func RunAllContext(ctx context.Context, db *gorm.DB, deps map[string]interface{}, opts RunOptions) error {
+	return registry.RunAllContext(ctx, db, deps, opts)
+}
+
+// RunSpecificContext executes a specific seeder by name with a context
+func RunSpecificContext(ctx context.Context, name string, db *gorm.DB, deps map[string]interface{}) error {
+	return registry.RunSpecificContext(ctx, name, db, deps)
+}
@@ -0,0 +1,184 @@
+package gorm_seed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// mockContextSeeder is a test seeder that implements ContextSeeder
+type mockContextSeeder struct {
+	mockSeeder
+	seedContextFunc func(ctx context.Context, db *gorm.DB, deps map[string]interface{}) error
+}
+
+func (m *mockContextSeeder) SeedContext(ctx context.Context, db *gorm.DB, deps map[string]interface{}) error {
+	return m.seedContextFunc(ctx, db, deps)
+}
+
+type contextKey string
+
+func TestRunAllContext_PlainSeederReceivesContext(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	ctx := context.WithValue(context.Background(), contextKey("run"), "42")
+
+	var received interface{}
+	Register(&mockSeeder{
+		name: "plain",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			received = db.Statement.Context.Value(contextKey("run"))
+			return nil
+		},
+	})
+
+	if err := RunAllContext(ctx, db, nil, RunOptions{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if received != "42" {
+		t.Errorf("expected db to carry the run context, got value %v", received)
+	}
+}
+
+func TestRunAllContext_ContextSeeder(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	ctx := context.WithValue(context.Background(), contextKey("run"), "42")
+
+	var received interface{}
+	Register(&mockContextSeeder{
+		mockSeeder: mockSeeder{
+			name: "aware",
+			seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+				t.Error("Seed should not be called for a ContextSeeder")
+				return nil
+			},
+		},
+		seedContextFunc: func(ctx context.Context, db *gorm.DB, deps map[string]interface{}) error {
+			received = ctx.Value(contextKey("run"))
+			return nil
+		},
+	})
+
+	if err := RunAllContext(ctx, db, nil, RunOptions{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if received != "42" {
+		t.Errorf("expected seeder to receive the run context, got value %v", received)
+	}
+}
+
+func TestRunAllContext_CancelBetweenSeeders(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	Register(&mockSeeder{
+		name: "001_cancels",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			cancel()
+			return nil
+		},
+	})
+	Register(&mockSeeder{
+		name: "002_never_runs",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			t.Error("seeder should not execute after the context is cancelled")
+			return nil
+		},
+	})
+
+	err := RunAllContext(ctx, db, nil, RunOptions{ContinueOnError: true})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got: %v", err)
+	}
+}
+
+func TestRunAllContext_SeederTimeout(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	Register(&mockContextSeeder{
+		mockSeeder: mockSeeder{name: "001_slow"},
+		seedContextFunc: func(ctx context.Context, db *gorm.DB, deps map[string]interface{}) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	executed := false
+	Register(&mockSeeder{
+		name: "002_fast",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			executed = true
+			return nil
+		},
+	})
+
+	err := RunAllContext(context.Background(), db, nil, RunOptions{
+		ContinueOnError: true,
+		SeederTimeout:   10 * time.Millisecond,
+	})
+
+	var seederErrs *SeederErrors
+	if !errors.As(err, &seederErrs) {
+		t.Fatalf("expected SeederErrors, got %T", err)
+	}
+	if len(seederErrs.Errors) != 1 || seederErrs.Errors[0].SeederName != "001_slow" {
+		t.Errorf("expected only 001_slow to fail, got %v", seederErrs.Errors)
+	}
+	if !errors.Is(seederErrs.Errors[0], context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got: %v", seederErrs.Errors[0].Err)
+	}
+
+	if !executed {
+		t.Error("expected the next seeder to run with a fresh timeout")
+	}
+}
+
+func TestRunSpecificContext_Cancelled(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	Register(&mockSeeder{
+		name: "queries",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			return db.Exec("SELECT 1").Error
+		},
+	})
+
+	err := RunSpecificContext(ctx, "queries", db, nil)
+
+	var seederErr *SeederError
+	if !errors.As(err, &seederErr) {
+		t.Fatalf("expected SeederError, got %T", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got: %v", err)
+	}
+}
+
+func TestAsContextSeeder(t *testing.T) {
+	plain := &mockSeeder{name: "plain"}
+	adapted := AsContextSeeder(plain)
+	if adapted.Name() != "plain" {
+		t.Errorf("expected adapted seeder to keep its name, got '%s'", adapted.Name())
+	}
+
+	aware := &mockContextSeeder{mockSeeder: mockSeeder{name: "aware"}}
+	if AsContextSeeder(aware) != ContextSeeder(aware) {
+		t.Error("expected a ContextSeeder to be returned unchanged")
+	}
+}
@@ -0,0 +1,63 @@
+package gorm_seed
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnknownDatabase is returned for a seeder whose database is not in
+// RunOptions.Databases
+var ErrUnknownDatabase = errors.New("unknown database")
+
+// DatabaseSeeder is implemented by seeders that write to one of several named
+// database connections, see RunOptions.Databases
+type DatabaseSeeder interface {
+	Seeder
+	// Database returns the name of the connection the seeder runs on. An
+	// empty name selects the db passed to the runner.
+	Database() string
+}
+
+// databaseOf returns the connection name declared by a seeder
+func databaseOf(seeder Seeder) string {
+	if databaseSeeder, ok := seeder.(DatabaseSeeder); ok {
+		return databaseSeeder.Database()
+	}
+	return ""
+}
+
+// usesNamedDatabase reports whether any seeder runs on a named connection
+func usesNamedDatabase(seeders []Seeder) bool {
+	for _, seeder := range seeders {
+		if databaseOf(seeder) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDatabases fails when a seeder names a connection missing from opts,
+// before any seeder runs
+func checkDatabases(seeders []Seeder, opts RunOptions) error {
+	for _, seeder := range seeders {
+		name := databaseOf(seeder)
+		if name == "" {
+			continue
+		}
+		if db, ok := opts.Databases[name]; !ok || db == nil {
+			return fmt.Errorf("%w %q of seeder %s", ErrUnknownDatabase, name, seeder.Name())
+		}
+	}
+	return nil
+}
+
+// databaseFor returns the handle the seeder runs on: its named connection,
+// or db when it declares none
+func databaseFor(seeder Seeder, db *gorm.DB, opts RunOptions) *gorm.DB {
+	if name := databaseOf(seeder); name != "" {
+		return opts.Databases[name]
+	}
+	return db
+}
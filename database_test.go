@@ -0,0 +1,111 @@
+package gorm_seed
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// mockDatabaseSeeder is a test seeder that runs on a named database
+type mockDatabaseSeeder struct {
+	mockSeeder
+	database string
+}
+
+func (m *mockDatabaseSeeder) Database() string {
+	return m.database
+}
+
+func TestRunAllWithOptions_NamedDatabases(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+	analytics := setupRecordDB(t)
+
+	Register(&mockSeeder{name: "001_users", seedFunc: insertRecord("main")})
+	Register(&mockDatabaseSeeder{mockSeeder: mockSeeder{name: "002_events", seedFunc: insertRecord("analytics")}, database: "analytics"})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		TrackHistory: true,
+		Databases:    map[string]*gorm.DB{"analytics": analytics},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if names := recordNames(t, db); len(names) != 1 || names[0] != "main" {
+		t.Errorf("expected only the main record in db, got %v", names)
+	}
+	if names := recordNames(t, analytics); len(names) != 1 || names[0] != "analytics" {
+		t.Errorf("expected only the analytics record in analytics, got %v", names)
+	}
+
+	// The history of both seeders lives in the main database
+	var count int64
+	db.Model(&SeedHistory{}).Count(&count)
+	if count != 2 {
+		t.Errorf("expected 2 history entries in db, got %d", count)
+	}
+	if analytics.Migrator().HasTable(DefaultHistoryTable) {
+		t.Error("expected no history table in the analytics database")
+	}
+}
+
+func TestRunAllWithOptions_NamedDatabaseTransaction(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+	analytics := setupRecordDB(t)
+
+	Register(&mockDatabaseSeeder{mockSeeder: mockSeeder{name: "001_events", seedFunc: insertThenFail("events")}, database: "analytics"})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Transaction: TransactionPerSeeder,
+		Databases:   map[string]*gorm.DB{"analytics": analytics},
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if names := recordNames(t, analytics); len(names) != 0 {
+		t.Errorf("expected the analytics write to be rolled back, got %v", names)
+	}
+}
+
+func TestRunAllWithOptions_UnknownDatabase(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	executed := false
+	Register(&mockSeeder{name: "001_users", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		executed = true
+		return nil
+	}})
+	Register(&mockDatabaseSeeder{mockSeeder: mockSeeder{name: "002_events"}, database: "analytics"})
+
+	err := RunAllWithOptions(db, nil, RunOptions{})
+	if !errors.Is(err, ErrUnknownDatabase) {
+		t.Fatalf("expected ErrUnknownDatabase, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `"analytics"`) || !strings.Contains(err.Error(), "002_events") {
+		t.Errorf("expected database and seeder in error, got: %v", err)
+	}
+	if executed {
+		t.Error("expected no seeder to run")
+	}
+}
+
+func TestRunAllWithOptions_NamedDatabaseTransactionAll(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	Register(&mockDatabaseSeeder{mockSeeder: mockSeeder{name: "001_events"}, database: "analytics"})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Transaction: TransactionAll,
+		Databases:   map[string]*gorm.DB{"analytics": setupTestDB(t)},
+	})
+	if err == nil || !strings.Contains(err.Error(), "TransactionAll") {
+		t.Errorf("expected TransactionAll to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package gorm_seed
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DependentSeeder is implemented by seeders that must run after other seeders
+type DependentSeeder interface {
+	Seeder
+	// DependsOn returns the names of the seeders that must run first
+	DependsOn() []string
+}
+
+// dependenciesOf returns the declared dependencies of a seeder
+func dependenciesOf(seeder Seeder) []string {
+	if dependent, ok := seeder.(DependentSeeder); ok {
+		return dependent.DependsOn()
+	}
+	return nil
+}
+
+// ExecutionOrder returns all registered seeders in the order they run:
+// dependencies first, with ties broken by name
+func ExecutionOrder() ([]Seeder, error) {
+	return registry.ExecutionOrder()
+}
+
+// ExecutionOrder returns the seeders of the registry in the order they run
+func (r *Registry) ExecutionOrder() ([]Seeder, error) {
+	return sortByDependencies(r.GetAll())
+}
+
+// sortByDependencies orders name-sorted seeders so that every seeder comes
+// after its dependencies. Among seeders that are ready to run, the one with
+// the smallest name goes first.
+func sortByDependencies(seeders []Seeder) ([]Seeder, error) {
+	index := make(map[string]int, len(seeders))
+	for i := len(seeders) - 1; i >= 0; i-- {
+		index[seeders[i].Name()] = i
+	}
+
+	// pending[i] counts the unresolved dependencies of seeders[i]
+	pending := make([]int, len(seeders))
+	dependents := make([][]int, len(seeders))
+	for i, seeder := range seeders {
+		for _, dep := range dependenciesOf(seeder) {
+			j, ok := index[dep]
+			if !ok {
+				return nil, fmt.Errorf("seeder %s depends on unknown seeder %s", seeder.Name(), dep)
+			}
+			pending[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	ready := make([]int, 0, len(seeders))
+	for i := range seeders {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	ordered := make([]Seeder, 0, len(seeders))
+	for len(ready) > 0 {
+		// ready is kept sorted, so the first entry has the smallest name
+		i := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, seeders[i])
+
+		for _, j := range dependents[i] {
+			pending[j]--
+			if pending[j] == 0 {
+				pos := sort.SearchInts(ready, j)
+				ready = append(ready, 0)
+				copy(ready[pos+1:], ready[pos:])
+				ready[pos] = j
+			}
+		}
+	}
+
+	if len(ordered) < len(seeders) {
+		cycle := findCycle(seeders, index, pending)
+		return nil, fmt.Errorf("dependency cycle detected: %s", strings.Join(cycle, " -> "))
+	}
+
+	return ordered, nil
+}
+
+// findCycle walks unresolved dependencies until a seeder repeats and returns
+// the names along the cycle, starting and ending with the same seeder
+func findCycle(seeders []Seeder, index map[string]int, pending []int) []string {
+	start := -1
+	for i := range seeders {
+		if pending[i] > 0 {
+			start = i
+			break
+		}
+	}
+
+	visited := make(map[int]int)
+	path := []int{}
+	for current := start; ; {
+		if pos, ok := visited[current]; ok {
+			names := make([]string, 0, len(path)-pos+1)
+			for _, i := range path[pos:] {
+				names = append(names, seeders[i].Name())
+			}
+			return append(names, seeders[current].Name())
+		}
+		visited[current] = len(path)
+		path = append(path, current)
+
+		// Every unresolved seeder has at least one unresolved dependency
+		for _, dep := range dependenciesOf(seeders[current]) {
+			if j := index[dep]; pending[j] > 0 {
+				current = j
+				break
+			}
+		}
+	}
+}
+
+// withDependencies returns the named seeder and its transitive dependencies
+// in execution order
+func withDependencies(seeders []Seeder, name string) ([]Seeder, error) {
+	byName := make(map[string]Seeder, len(seeders))
+	for i := len(seeders) - 1; i >= 0; i-- {
+		byName[seeders[i].Name()] = seeders[i]
+	}
+
+	if _, ok := byName[name]; !ok {
+		return nil, fmt.Errorf("seeder not found: %s", name)
+	}
+
+	included := map[string]bool{name: true}
+	queue := []string{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, dep := range dependenciesOf(byName[current]) {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("seeder %s depends on unknown seeder %s", current, dep)
+			}
+			if !included[dep] {
+				included[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	subset := make([]Seeder, 0, len(included))
+	for _, seeder := range seeders {
+		if included[seeder.Name()] {
+			subset = append(subset, seeder)
+			delete(included, seeder.Name())
+		}
+	}
+
+	return sortByDependencies(subset)
+}
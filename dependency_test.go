@@ -0,0 +1,202 @@
+package gorm_seed
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// mockDependentSeeder is a test seeder that declares dependencies
+type mockDependentSeeder struct {
+	mockSeeder
+	deps []string
+}
+
+func (m *mockDependentSeeder) DependsOn() []string {
+	return m.deps
+}
+
+func seederNames(seeders []Seeder) []string {
+	names := make([]string, len(seeders))
+	for i, seeder := range seeders {
+		names[i] = seeder.Name()
+	}
+	return names
+}
+
+func TestExecutionOrder_Dependencies(t *testing.T) {
+	Clear()
+
+	Register(&mockDependentSeeder{mockSeeder: mockSeeder{name: "orders"}, deps: []string{"users", "products"}})
+	Register(&mockSeeder{name: "users"})
+	Register(&mockDependentSeeder{mockSeeder: mockSeeder{name: "products"}, deps: []string{"categories"}})
+	Register(&mockSeeder{name: "categories"})
+	Register(&mockSeeder{name: "audit"})
+
+	seeders, err := ExecutionOrder()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := []string{"audit", "categories", "products", "users", "orders"}
+	got := seederNames(seeders)
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected order %v, got %v", expected, got)
+	}
+}
+
+func TestExecutionOrder_NameTieBreak(t *testing.T) {
+	Clear()
+
+	Register(&mockSeeder{name: "003_c"})
+	Register(&mockSeeder{name: "001_a"})
+	Register(&mockDependentSeeder{mockSeeder: mockSeeder{name: "002_b"}, deps: []string{"003_c"}})
+
+	seeders, err := ExecutionOrder()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := []string{"001_a", "003_c", "002_b"}
+	got := seederNames(seeders)
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected order %v, got %v", expected, got)
+	}
+}
+
+func TestExecutionOrder_UnknownDependency(t *testing.T) {
+	Clear()
+
+	Register(&mockDependentSeeder{mockSeeder: mockSeeder{name: "orders"}, deps: []string{"customers"}})
+
+	_, err := ExecutionOrder()
+	if err == nil {
+		t.Fatal("expected error for unknown dependency, got nil")
+	}
+	if !strings.Contains(err.Error(), "seeder orders depends on unknown seeder customers") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestExecutionOrder_Cycle(t *testing.T) {
+	Clear()
+
+	Register(&mockDependentSeeder{mockSeeder: mockSeeder{name: "a"}, deps: []string{"b"}})
+	Register(&mockDependentSeeder{mockSeeder: mockSeeder{name: "b"}, deps: []string{"c"}})
+	Register(&mockDependentSeeder{mockSeeder: mockSeeder{name: "c"}, deps: []string{"a"}})
+	Register(&mockSeeder{name: "d"})
+
+	_, err := ExecutionOrder()
+	if err == nil {
+		t.Fatal("expected error for dependency cycle, got nil")
+	}
+	if !strings.Contains(err.Error(), "dependency cycle detected: a -> b -> c -> a") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestRunAll_DependencyCycle(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	Register(&mockDependentSeeder{
+		mockSeeder: mockSeeder{
+			name: "self",
+			seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+				t.Error("seeder should not execute when dependencies are invalid")
+				return nil
+			},
+		},
+		deps: []string{"self"},
+	})
+
+	if err := RunAll(db, nil); err == nil {
+		t.Error("expected error for dependency cycle, got nil")
+	}
+}
+
+func TestRunAllWithOptions_FailedDependency(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	executed := []string{}
+
+	Register(&mockSeeder{
+		name: "001_users",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			return errors.New("intentional failure")
+		},
+	})
+	Register(&mockDependentSeeder{
+		mockSeeder: mockSeeder{
+			name: "002_orders",
+			seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+				executed = append(executed, "002_orders")
+				return nil
+			},
+		},
+		deps: []string{"001_users"},
+	})
+	Register(&mockSeeder{
+		name: "003_products",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			executed = append(executed, "003_products")
+			return nil
+		},
+	})
+
+	err := RunAllWithOptions(db, nil, RunOptions{ContinueOnError: true})
+
+	var seederErrs *SeederErrors
+	if !errors.As(err, &seederErrs) {
+		t.Fatalf("expected SeederErrors, got %T", err)
+	}
+	if len(seederErrs.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(seederErrs.Errors))
+	}
+	if seederErrs.Errors[1].SeederName != "002_orders" || seederErrs.Errors[1].Err.Error() != "dependency 001_users failed" {
+		t.Errorf("unexpected error for dependent seeder: %v", seederErrs.Errors[1])
+	}
+
+	if len(executed) != 1 || executed[0] != "003_products" {
+		t.Errorf("expected only 003_products to execute, got %v", executed)
+	}
+}
+
+func TestRunSpecificWithDependencies(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	executed := []string{}
+	record := func(name string) func(db *gorm.DB, deps map[string]interface{}) error {
+		return func(db *gorm.DB, deps map[string]interface{}) error {
+			executed = append(executed, name)
+			return nil
+		}
+	}
+
+	Register(&mockSeeder{name: "roles", seedFunc: record("roles")})
+	Register(&mockDependentSeeder{mockSeeder: mockSeeder{name: "users", seedFunc: record("users")}, deps: []string{"roles"}})
+	Register(&mockDependentSeeder{mockSeeder: mockSeeder{name: "orders", seedFunc: record("orders")}, deps: []string{"users"}})
+	Register(&mockSeeder{name: "products", seedFunc: record("products")})
+
+	if err := RunSpecificWithDependencies("orders", db, nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := []string{"roles", "users", "orders"}
+	if strings.Join(executed, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v to execute, got %v", expected, executed)
+	}
+}
+
+func TestRunSpecificWithDependencies_NotFound(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	if err := RunSpecificWithDependencies("non_existent", db, nil); err == nil {
+		t.Error("expected error for non-existent seeder, got nil")
+	}
+}
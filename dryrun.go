@@ -0,0 +1,84 @@
+package gorm_seed
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SeederSQL holds the statements a seeder would have executed in a dry run
+type SeederSQL struct {
+	SeederName string
+	Statements []string
+}
+
+// sqlRecorder is a gorm logger that records every statement, with its bound
+// values, instead of printing it. Other log output goes to the wrapped logger.
+type sqlRecorder struct {
+	logger.Interface
+
+	mu         sync.Mutex
+	statements []string
+}
+
+// LogMode keeps the recorder in place when a seeder changes the log level
+func (r *sqlRecorder) LogMode(level logger.LogLevel) logger.Interface {
+	return r
+}
+
+// Trace records the statement
+func (r *sqlRecorder) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	sql, _ := fc()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statements = append(r.statements, sql)
+}
+
+// dryRun runs fn against a dry-run session of db and returns the SQL it built
+func dryRun(db *gorm.DB, fn func(tx *gorm.DB) error) ([]string, error) {
+	recorder := &sqlRecorder{Interface: db.Logger}
+	err := fn(db.Session(&gorm.Session{
+		DryRun: true,
+		Logger: recorder,
+	}))
+	return recorder.statements, err
+}
+
+// DryRunAll runs all registered seeders against a dry-run session, see
+// RunOptions.DryRun, and returns the SQL of each seeder in the order they ran
+func DryRunAll(db *gorm.DB, deps map[string]interface{}, opts RunOptions) ([]SeederSQL, error) {
+	return registry.DryRunAll(db, deps, opts)
+}
+
+// DryRunAll runs the seeders of the registry against a dry-run session
+func (r *Registry) DryRunAll(db *gorm.DB, deps map[string]interface{}, opts RunOptions) ([]SeederSQL, error) {
+	return NewRunner(r, opts).DryRunAll(context.Background(), db, deps)
+}
+
+// DryRunAll runs the selected seeders against a dry-run session and returns
+// the SQL of each seeder in the order they ran. The runner's OnSeederSQL
+// callback is still called.
+func (r *Runner) DryRunAll(ctx context.Context, db *gorm.DB, deps map[string]interface{}) ([]SeederSQL, error) {
+	var mu sync.Mutex
+	var results []SeederSQL
+
+	opts := r.opts
+	opts.DryRun = true
+	onSQL := opts.OnSeederSQL
+	opts.OnSeederSQL = func(name string, statements []string) {
+		mu.Lock()
+		results = append(results, SeederSQL{SeederName: name, Statements: statements})
+		mu.Unlock()
+
+		if onSQL != nil {
+			onSQL(name, statements)
+		}
+	}
+
+	err := NewRunner(r.registry, opts).RunAll(ctx, db, deps)
+	return results, err
+}
@@ -0,0 +1,110 @@
+package gorm_seed
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestDryRunAll(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{name: "001_users", seedFunc: insertRecord("alice")})
+	Register(&mockSeeder{
+		name: "002_rename",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			return db.Model(&testRecord{}).Where("name = ?", "alice").Update("name", "bob").Error
+		},
+	})
+	Register(&mockSeeder{name: "003_empty"})
+
+	results, err := DryRunAll(db, nil, RunOptions{Transaction: TransactionAll})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected results for 3 seeders, got %d", len(results))
+	}
+	if results[0].SeederName != "001_users" || len(results[0].Statements) != 1 ||
+		!strings.Contains(results[0].Statements[0], "INSERT INTO `test_records`") ||
+		!strings.Contains(results[0].Statements[0], `"alice"`) {
+		t.Errorf("expected insert with bound values for 001_users, got %+v", results[0])
+	}
+	if len(results[1].Statements) != 1 || !strings.Contains(results[1].Statements[0], `"bob"`) {
+		t.Errorf("expected update for 002_rename, got %+v", results[1])
+	}
+	if len(results[2].Statements) != 0 {
+		t.Errorf("expected no statements for 003_empty, got %v", results[2].Statements)
+	}
+
+	if names := recordNames(t, db); len(names) != 0 {
+		t.Errorf("expected nothing to be written, got %v", names)
+	}
+}
+
+func TestDryRunAll_HistoryIsReadOnly(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{name: "001_users", seedFunc: insertRecord("alice")})
+
+	if _, err := DryRunAll(db, nil, RunOptions{SkipExecuted: true}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if db.Migrator().HasTable(DefaultHistoryTable) {
+		t.Fatal("expected dry run not to create the history table")
+	}
+
+	if err := RunAllWithOptions(db, nil, RunOptions{SkipExecuted: true}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	skipped := []string{}
+	results, err := DryRunAll(db, nil, RunOptions{
+		SkipExecuted: true,
+		OnSeederSkip: func(name string) {
+			skipped = append(skipped, name)
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(results) != 0 || len(skipped) != 1 {
+		t.Errorf("expected executed seeder to be skipped, got results %v and skipped %v", results, skipped)
+	}
+
+	var count int64
+	db.Model(&SeedHistory{}).Count(&count)
+	if count != 1 {
+		t.Errorf("expected dry run not to record history, got %d records", count)
+	}
+}
+
+func TestRunAllWithOptions_DryRunCallback(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{name: "001_users", seedFunc: insertRecord("alice")})
+
+	reported := map[string][]string{}
+	err := RunAllWithOptions(db, nil, RunOptions{
+		DryRun:      true,
+		Concurrency: 2,
+		OnSeederSQL: func(name string, statements []string) {
+			reported[name] = statements
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(reported["001_users"]) != 1 {
+		t.Errorf("expected 1 statement for 001_users, got %v", reported)
+	}
+	if names := recordNames(t, db); len(names) != 0 {
+		t.Errorf("expected nothing to be written, got %v", names)
+	}
+}
@@ -0,0 +1,271 @@
+package gorm_seed
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// EventType identifies what happened to a seeder
+type EventType int
+
+const (
+	// EventStart is emitted before a seeder runs
+	EventStart EventType = iota
+	// EventFinish is emitted after a seeder completed successfully
+	EventFinish
+	// EventSkip is emitted for a seeder that does not run, see Event.Reason
+	EventSkip
+	// EventError is emitted after a seeder failed
+	EventError
+	// EventRetry is emitted when a failed attempt of a seeder is retried
+	EventRetry
+	// EventRolledBack is emitted once when the transaction of a failed
+	// TransactionAll run is rolled back, undoing every seeder that finished.
+	// Its SeederName is empty.
+	EventRolledBack
+)
+
+// String returns the name of the event type
+func (t EventType) String() string {
+	switch t {
+	case EventStart:
+		return "start"
+	case EventFinish:
+		return "finish"
+	case EventSkip:
+		return "skip"
+	case EventError:
+		return "error"
+	case EventRetry:
+		return "retry"
+	case EventRolledBack:
+		return "rolled_back"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// Reasons reported by skip events
+const (
+	// ReasonExecuted means the history shows a run that the seeder's run policy accepts
+	ReasonExecuted = "already executed"
+	// ReasonNotReversible means a rollback met a seeder without Unseed
+	ReasonNotReversible = "not reversible"
+	// ReasonEarlierFailure means a fail-fast run stopped before the seeder
+	ReasonEarlierFailure = "not run: earlier failure"
+	// ReasonCancelled means the run's context was done before the seeder started
+	ReasonCancelled = "cancelled"
+)
+
+// Event describes a step of a run
+type Event struct {
+	Type EventType
+	// RunID identifies the run the event belongs to
+	RunID string
+	// RandomSeed is the seed of the run's fake data, see RunOptions.RandomSeed
+	RandomSeed int64
+	SeederName string
+	// Rollback is true when the seeder is unseeded
+	Rollback bool
+	// Time is when the event was emitted
+	Time time.Time
+
+	// Attempt is the attempt of the seeder the event reports on (finish,
+	// error and retry events)
+	Attempt int
+	// Duration is how long the seeder ran (finish and error events), or the
+	// failed attempt took (retry events)
+	Duration time.Duration
+	// RowsAffected counts the rows created, updated or deleted per table
+	// (finish and error events). Raw SQL is counted under an empty table name.
+	RowsAffected map[string]int64
+	// Statements holds the SQL of a dry run (finish and error events)
+	Statements []string
+
+	// Reason explains a skip event
+	Reason string
+	// Err is the error of an error or retry event
+	Err error
+}
+
+// Observer receives the events of a run. Events of a run are delivered one
+// at a time, even when seeders run concurrently.
+type Observer interface {
+	OnEvent(event Event)
+}
+
+// ObserverFunc adapts a function to the Observer interface
+type ObserverFunc func(event Event)
+
+// OnEvent calls f(event)
+func (f ObserverFunc) OnEvent(event Event) {
+	f(event)
+}
+
+// eventEmitter delivers the events of a single run to its observers
+type eventEmitter struct {
+	runID      string
+	randomSeed int64
+	mu         sync.Mutex
+	observers  []Observer
+}
+
+// newEventEmitter creates the emitter for a run, delivering to opts.Observers
+// and to the callbacks in opts
+func newEventEmitter(opts RunOptions) (*eventEmitter, error) {
+	runID := opts.RunID
+	if runID == "" {
+		var err error
+		if runID, err = newRunID(); err != nil {
+			return nil, err
+		}
+	}
+
+	observers := make([]Observer, 0, len(opts.Observers)+1)
+	observers = append(observers, opts.Observers...)
+	observers = append(observers, callbackObserver(opts))
+
+	return &eventEmitter{
+		runID:      runID,
+		randomSeed: opts.RandomSeed,
+		observers:  observers,
+	}, nil
+}
+
+// emit stamps the event and delivers it to every observer
+func (e *eventEmitter) emit(event Event) {
+	if e == nil {
+		return
+	}
+
+	event.RunID = e.runID
+	event.RandomSeed = e.randomSeed
+	event.Time = time.Now()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, observer := range e.observers {
+		observer.OnEvent(event)
+	}
+}
+
+// newRunID returns a random run identifier
+func newRunID() (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate run id: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// callbackObserver calls the OnSeeder* callbacks of opts for each event
+func callbackObserver(opts RunOptions) Observer {
+	return ObserverFunc(func(event Event) {
+		switch event.Type {
+		case EventStart:
+			if opts.OnSeederStart != nil {
+				opts.OnSeederStart(event.SeederName)
+			}
+		case EventSkip:
+			// The callback has no reason, so it only reports seeders skipped
+			// on their own account
+			if event.Reason != ReasonExecuted && event.Reason != ReasonNotReversible {
+				return
+			}
+			if opts.OnSeederSkip != nil {
+				opts.OnSeederSkip(event.SeederName)
+			}
+		case EventRetry:
+			if opts.OnSeederRetry != nil {
+				opts.OnSeederRetry(event.SeederName, event.Attempt, event.Err)
+			}
+		case EventFinish, EventError:
+			if opts.DryRun && opts.OnSeederSQL != nil {
+				opts.OnSeederSQL(event.SeederName, event.Statements)
+			}
+			if event.Type == EventFinish && opts.OnSeederComplete != nil {
+				opts.OnSeederComplete(event.SeederName)
+			}
+			if event.Type == EventError && opts.OnSeederError != nil {
+				opts.OnSeederError(event.SeederName, event.Err)
+			}
+		}
+	})
+}
+
+// rowCounter collects the rows affected per table by a seeder's session
+type rowCounter struct {
+	mu   sync.Mutex
+	rows map[string]int64
+}
+
+// rowCounterKey is the context key of the rowCounter of a seeder
+type rowCounterKey struct{}
+
+// rowsCallbackName is the name of the GORM callbacks counting affected rows
+const rowsCallbackName = "gorm_seed:rows_affected"
+
+// rowCallbacksMu serializes registering the row callbacks
+var rowCallbacksMu sync.Mutex
+
+// withRowCounter returns a context whose queries are counted by counter
+func withRowCounter(ctx context.Context, counter *rowCounter) context.Context {
+	return context.WithValue(ctx, rowCounterKey{}, counter)
+}
+
+// registerRowCallbacks adds the callbacks that count affected rows to db,
+// once per gorm.DB. The callbacks only count queries whose context carries a
+// rowCounter, so other users of db are unaffected.
+func registerRowCallbacks(db *gorm.DB) error {
+	rowCallbacksMu.Lock()
+	defer rowCallbacksMu.Unlock()
+
+	callbacks := db.Callback()
+	if callbacks.Create().Get(rowsCallbackName) != nil {
+		return nil
+	}
+
+	if err := callbacks.Create().After("gorm:create").Register(rowsCallbackName, countRows); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register(rowsCallbackName, countRows); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register(rowsCallbackName, countRows); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register(rowsCallbackName, countRows)
+}
+
+// countRows adds the rows affected by a statement to the seeder's rowCounter
+func countRows(db *gorm.DB) {
+	if db.Statement.Context == nil || db.RowsAffected <= 0 {
+		return
+	}
+	counter, ok := db.Statement.Context.Value(rowCounterKey{}).(*rowCounter)
+	if !ok {
+		return
+	}
+
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	counter.rows[db.Statement.Table] += db.RowsAffected
+}
+
+// snapshot returns a copy of the counted rows
+func (c *rowCounter) snapshot() map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rows := make(map[string]int64, len(c.rows))
+	for table, n := range c.rows {
+		rows[table] = n
+	}
+	return rows
+}
@@ -0,0 +1,177 @@
+package gorm_seed
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// recordEvents returns an observer that appends every event to events
+func recordEvents(events *[]Event) Observer {
+	return ObserverFunc(func(event Event) {
+		*events = append(*events, event)
+	})
+}
+
+func TestRunAllWithOptions_Events(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{
+		name: "001_records",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			records := []testRecord{{Name: "alice"}, {Name: "bob"}, {Name: "carol"}}
+			if err := db.Create(&records).Error; err != nil {
+				return err
+			}
+			if err := db.Model(&testRecord{}).Where("name = ?", "bob").Update("name", "bobby").Error; err != nil {
+				return err
+			}
+			return db.Exec("DELETE FROM test_records WHERE name = ?", "carol").Error
+		},
+	})
+	Register(&mockSeeder{name: "002_empty"})
+
+	events := []Event{}
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Observers: []Observer{recordEvents(&events)},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	types := []EventType{EventStart, EventFinish, EventStart, EventFinish}
+	if len(events) != len(types) {
+		t.Fatalf("expected %d events, got %d: %+v", len(types), len(events), events)
+	}
+	for i, eventType := range types {
+		if events[i].Type != eventType {
+			t.Errorf("expected event %d to be %s, got %s", i, eventType, events[i].Type)
+		}
+		if events[i].RunID == "" || events[i].RunID != events[0].RunID {
+			t.Errorf("expected every event to carry the same run id, got '%s'", events[i].RunID)
+		}
+		if events[i].Time.IsZero() {
+			t.Errorf("expected event %d to carry a time", i)
+		}
+	}
+
+	finish := events[1]
+	if finish.SeederName != "001_records" || finish.Duration <= 0 {
+		t.Errorf("unexpected finish event: %+v", finish)
+	}
+	if finish.RowsAffected["test_records"] != 4 || finish.RowsAffected[""] != 1 {
+		t.Errorf("expected 4 rows in test_records and 1 raw row, got %v", finish.RowsAffected)
+	}
+	if len(events[3].RowsAffected) != 0 {
+		t.Errorf("expected no rows for 002_empty, got %v", events[3].RowsAffected)
+	}
+
+	// Queries outside a seeder are not counted
+	if err := db.Create(&testRecord{Name: "dave"}).Error; err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if finish.RowsAffected["test_records"] != 4 {
+		t.Errorf("expected rows outside the seeder not to be counted, got %v", finish.RowsAffected)
+	}
+}
+
+func TestRunAllWithOptions_EventsSkipAndError(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	Register(&mockSeeder{name: "001_users"})
+	Register(&mockSeeder{
+		name: "002_failing",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			return errors.New("intentional failure")
+		},
+	})
+
+	opts := RunOptions{ContinueOnError: true, SkipExecuted: true, RunID: "first"}
+	RunAllWithOptions(db, nil, opts)
+
+	events := []Event{}
+	completed := []string{}
+	opts.RunID = "second"
+	opts.Observers = []Observer{recordEvents(&events)}
+	opts.OnSeederComplete = func(name string) {
+		completed = append(completed, name)
+	}
+	RunAllWithOptions(db, nil, opts)
+
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d: %+v", len(events), events)
+	}
+	if events[0].Type != EventSkip || events[0].Reason != ReasonExecuted || events[0].RunID != "second" {
+		t.Errorf("unexpected skip event: %+v", events[0])
+	}
+	if events[2].Type != EventError || events[2].Err == nil || events[2].Err.Error() != "intentional failure" {
+		t.Errorf("unexpected error event: %+v", events[2])
+	}
+	if len(completed) != 0 {
+		t.Errorf("expected no completed callbacks, got %v", completed)
+	}
+}
+
+func TestRunAllWithOptions_SkipCallbackOnlyForSkippedSeeders(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	Register(&mockSeeder{name: "001_users"})
+	Register(&mockSeeder{
+		name: "002_failing",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			return errors.New("intentional failure")
+		},
+	})
+	Register(&mockSeeder{name: "003_orders"})
+
+	opts := RunOptions{TrackHistory: true}
+	RunAllWithOptions(db, nil, opts)
+
+	// 001_users is skipped as executed, 003_orders is never reached
+	skipped := []string{}
+	opts.SkipExecuted = true
+	opts.OnSeederSkip = func(name string) {
+		skipped = append(skipped, name)
+	}
+	RunAllWithOptions(db, nil, opts)
+
+	if len(skipped) != 1 || skipped[0] != "001_users" {
+		t.Errorf("expected only 001_users to be reported as skipped, got %v", skipped)
+	}
+}
+
+func TestRollbackAll_Events(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	unseeded := []string{}
+	registerReversible("001_users", &unseeded)
+	Register(&mockSeeder{name: "002_settings"})
+
+	events := []Event{}
+	if err := RollbackAll(db, nil, RunOptions{Observers: []Observer{recordEvents(&events)}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d: %+v", len(events), events)
+	}
+	if events[0].Type != EventSkip || events[0].Reason != ReasonNotReversible {
+		t.Errorf("unexpected skip event: %+v", events[0])
+	}
+	for _, event := range events {
+		if !event.Rollback {
+			t.Errorf("expected rollback events, got %+v", event)
+		}
+	}
+}
+
+func TestEventType_String(t *testing.T) {
+	if EventFinish.String() != "finish" || EventRolledBack.String() != "rolled_back" || EventType(9).String() != "EventType(9)" {
+		t.Errorf("unexpected event type names: %s, %s", EventFinish, EventType(9))
+	}
+}
@@ -0,0 +1,100 @@
+package gorm_seed
+
+import (
+	"reflect"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// factoryBatchSize is the number of rows Factory.Create inserts per statement
+const factoryBatchSize = 100
+
+// Trait changes a model built by a Factory, e.g. to make a user an admin
+type Trait[T any] func(model *T)
+
+// Factory builds fake instances of a model from a definition and traits. The
+// definition receives a sequence number that starts at 1 and grows with every
+// instance, so unique attributes like emails can be derived from it. A
+// Factory is safe for concurrent use.
+type Factory[T any] struct {
+	define       func(seq int) T
+	traits       []Trait[T]
+	associations []factoryAssociation
+	seq          *int64
+}
+
+// NewFactory creates a factory whose instances start from define, e.g.
+//
+//	users := NewFactory(func(seq int) User {
+//		return User{Name: fmt.Sprintf("User %d", seq), Email: fmt.Sprintf("user%d@example.com", seq)}
+//	})
+func NewFactory[T any](define func(seq int) T) *Factory[T] {
+	return &Factory[T]{
+		define: define,
+		seq:    new(int64),
+	}
+}
+
+// With returns a factory that applies the traits, in order, after the traits
+// of f. Both factories share the sequence.
+func (f *Factory[T]) With(traits ...Trait[T]) *Factory[T] {
+	combined := make([]Trait[T], 0, len(f.traits)+len(traits))
+	combined = append(combined, f.traits...)
+	combined = append(combined, traits...)
+	return &Factory[T]{
+		define:       f.define,
+		traits:       combined,
+		associations: f.associations,
+		seq:          f.seq,
+	}
+}
+
+// Sequence returns the sequence number of the last built instance
+func (f *Factory[T]) Sequence() int {
+	return int(atomic.LoadInt64(f.seq))
+}
+
+// ResetSequence restarts the sequence at 1
+func (f *Factory[T]) ResetSequence() {
+	atomic.StoreInt64(f.seq, 0)
+}
+
+// BuildOne builds a single instance without saving it
+func (f *Factory[T]) BuildOne() T {
+	model := f.define(int(atomic.AddInt64(f.seq, 1)))
+	for _, association := range f.associations {
+		association.set(reflect.ValueOf(&model).Elem())
+	}
+	for _, trait := range f.traits {
+		trait(&model)
+	}
+	return model
+}
+
+// Build builds n instances without saving them
+func (f *Factory[T]) Build(n int) []T {
+	models := make([]T, 0, n)
+	for i := 0; i < n; i++ {
+		models = append(models, f.BuildOne())
+	}
+	return models
+}
+
+// CreateOne builds a single instance and inserts it
+func (f *Factory[T]) CreateOne(db *gorm.DB) (T, error) {
+	model := f.BuildOne()
+	err := db.Create(&model).Error
+	return model, err
+}
+
+// Create builds n instances and inserts them in batches. The returned
+// instances hold the primary keys assigned by the database.
+func (f *Factory[T]) Create(db *gorm.DB, n int) ([]T, error) {
+	models := f.Build(n)
+	if len(models) == 0 {
+		return models, nil
+	}
+	err := db.CreateInBatches(&models, factoryBatchSize).Error
+	return models, err
+}
@@ -0,0 +1,143 @@
+package gorm_seed
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// AssociationSource provides the instances of a factory association: a
+// *Factory builds new ones, Reuse hands out existing ones
+type AssociationSource interface {
+	// associationType returns the model type of the instances
+	associationType() reflect.Type
+	// associationValue returns the next instance
+	associationValue() reflect.Value
+}
+
+func (f *Factory[T]) associationType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (f *Factory[T]) associationValue() reflect.Value {
+	model := f.BuildOne()
+	return reflect.ValueOf(&model).Elem()
+}
+
+// reuseSource hands out existing instances in turn
+type reuseSource[C any] struct {
+	instances []C
+	next      *int64
+}
+
+// Reuse returns a source that assigns the given instances in turn instead of
+// building new ones, e.g. customers created earlier with their primary keys
+func Reuse[C any](instances ...C) AssociationSource {
+	return &reuseSource[C]{
+		instances: instances,
+		next:      new(int64),
+	}
+}
+
+func (r *reuseSource[C]) associationType() reflect.Type {
+	return reflect.TypeOf((*C)(nil)).Elem()
+}
+
+func (r *reuseSource[C]) associationValue() reflect.Value {
+	if len(r.instances) == 0 {
+		return reflect.Zero(r.associationType())
+	}
+	i := (atomic.AddInt64(r.next, 1) - 1) % int64(len(r.instances))
+	return reflect.ValueOf(&r.instances[i]).Elem()
+}
+
+// factoryAssociation fills an association field of built instances
+type factoryAssociation struct {
+	field  string
+	source AssociationSource
+	count  int
+}
+
+// set fills the association field of model, a settable struct value
+func (a factoryAssociation) set(model reflect.Value) {
+	field := model.FieldByName(a.field)
+	if field.Kind() != reflect.Slice {
+		field.Set(associationValueOf(a.source.associationValue(), field.Type()))
+		return
+	}
+
+	values := reflect.MakeSlice(field.Type(), 0, a.count)
+	for i := 0; i < a.count; i++ {
+		values = reflect.Append(values, associationValueOf(a.source.associationValue(), field.Type().Elem()))
+	}
+	field.Set(values)
+}
+
+// associationValueOf converts an instance to the field type, taking the
+// address of a copy for pointer fields
+func associationValueOf(value reflect.Value, typ reflect.Type) reflect.Value {
+	if typ.Kind() != reflect.Ptr {
+		return value
+	}
+	ptr := reflect.New(typ.Elem())
+	ptr.Elem().Set(value)
+	return ptr
+}
+
+// Association returns a factory that fills the association field of every
+// instance from source: one instance for a belongs-to or has-one field, count
+// instances for a has-many or many2many slice. Saving the parent with Create
+// inserts the associated instances through the same db, and GORM sets the
+// foreign keys and join rows following the field's relationship tags.
+//
+// A later declaration for the same field replaces the earlier one. Association
+// panics when T has no exported field of that name holding source's type.
+func (f *Factory[T]) Association(field string, source AssociationSource, count int) *Factory[T] {
+	modelType := reflect.TypeOf((*T)(nil)).Elem()
+	if err := checkAssociation(modelType, field, source.associationType()); err != nil {
+		panic(err)
+	}
+
+	associations := make([]factoryAssociation, 0, len(f.associations)+1)
+	for _, association := range f.associations {
+		if association.field != field {
+			associations = append(associations, association)
+		}
+	}
+	associations = append(associations, factoryAssociation{
+		field:  field,
+		source: source,
+		count:  count,
+	})
+
+	return &Factory[T]{
+		define:       f.define,
+		traits:       f.traits,
+		associations: associations,
+		seq:          f.seq,
+	}
+}
+
+// checkAssociation reports whether the field of modelType can hold instances
+// of assocType, directly, by pointer or as slice elements
+func checkAssociation(modelType reflect.Type, field string, assocType reflect.Type) error {
+	if modelType.Kind() != reflect.Struct {
+		return fmt.Errorf("factory association %s: %s is not a struct", field, modelType)
+	}
+	structField, ok := modelType.FieldByName(field)
+	if !ok || !structField.IsExported() {
+		return fmt.Errorf("factory association %s: %s has no exported field %s", field, modelType, field)
+	}
+
+	typ := structField.Type
+	if typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ != assocType {
+		return fmt.Errorf("factory association %s: field of type %s cannot hold %s", field, structField.Type, assocType)
+	}
+	return nil
+}
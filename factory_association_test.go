@@ -0,0 +1,196 @@
+package gorm_seed
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// Models of an object graph built by factories in tests
+type testCompany struct {
+	ID   uint
+	Name string
+}
+
+type testProfile struct {
+	ID        uint
+	AccountID uint
+	Bio       string
+}
+
+type testComment struct {
+	ID     uint
+	PostID uint
+	Body   string
+}
+
+type testPost struct {
+	ID        uint
+	AccountID uint
+	Title     string
+	Comments  []testComment `gorm:"foreignKey:PostID"`
+}
+
+type testLabel struct {
+	ID   uint
+	Name string
+}
+
+type testAccount struct {
+	ID        uint
+	Email     string
+	CompanyID uint
+	Company   testCompany
+	Profile   *testProfile `gorm:"foreignKey:AccountID"`
+	Posts     []testPost   `gorm:"foreignKey:AccountID"`
+	Labels    []testLabel  `gorm:"many2many:test_account_labels"`
+}
+
+func setupAccountDB(t *testing.T) *gorm.DB {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&testCompany{}, &testAccount{}, &testProfile{}, &testPost{}, &testComment{}, &testLabel{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+func newAccountFactories() (*Factory[testCompany], *Factory[testAccount]) {
+	companies := NewFactory(func(seq int) testCompany {
+		return testCompany{Name: fmt.Sprintf("Company %d", seq)}
+	})
+	comments := NewFactory(func(seq int) testComment {
+		return testComment{Body: fmt.Sprintf("Comment %d", seq)}
+	})
+	posts := NewFactory(func(seq int) testPost {
+		return testPost{Title: fmt.Sprintf("Post %d", seq)}
+	}).Association("Comments", comments, 2)
+	profiles := NewFactory(func(seq int) testProfile {
+		return testProfile{Bio: fmt.Sprintf("Bio %d", seq)}
+	})
+	labels := NewFactory(func(seq int) testLabel {
+		return testLabel{Name: fmt.Sprintf("Label %d", seq)}
+	})
+
+	accounts := NewFactory(func(seq int) testAccount {
+		return testAccount{Email: fmt.Sprintf("account%d@example.com", seq)}
+	}).
+		Association("Company", companies, 1).
+		Association("Profile", profiles, 1).
+		Association("Posts", posts, 3).
+		Association("Labels", labels, 2)
+	return companies, accounts
+}
+
+func countModelRows(t *testing.T, db *gorm.DB, model interface{}) int64 {
+	var count int64
+	if err := db.Model(model).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count rows: %v", err)
+	}
+	return count
+}
+
+func TestFactory_CreateAssociations(t *testing.T) {
+	Clear()
+	db := setupAccountDB(t)
+
+	_, accounts := newAccountFactories()
+	Register(&mockSeeder{name: "001_accounts", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		_, err := accounts.Create(db, 2)
+		return err
+	}})
+
+	err := RunAllWithOptions(db, nil, RunOptions{Transaction: TransactionPerSeeder})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	counts := map[string]int64{
+		"companies": countModelRows(t, db, &testCompany{}),
+		"profiles":  countModelRows(t, db, &testProfile{}),
+		"posts":     countModelRows(t, db, &testPost{}),
+		"comments":  countModelRows(t, db, &testComment{}),
+		"labels":    countModelRows(t, db, &testLabel{}),
+		"joins":     countModelRows(t, db.Table("test_account_labels"), nil),
+	}
+	expected := map[string]int64{"companies": 2, "profiles": 2, "posts": 6, "comments": 12, "labels": 4, "joins": 4}
+	for name, count := range expected {
+		if counts[name] != count {
+			t.Errorf("expected %d %s, got %d", count, name, counts[name])
+		}
+	}
+
+	var loaded []testAccount
+	if err := db.Preload("Company").Preload("Profile").Preload("Posts.Comments").Preload("Labels").Order("id").Find(&loaded).Error; err != nil {
+		t.Fatalf("failed to load accounts: %v", err)
+	}
+	for _, account := range loaded {
+		if account.Company.ID == 0 || account.Company.ID != account.CompanyID {
+			t.Errorf("expected company of %s, got %+v", account.Email, account.Company)
+		}
+		if account.Profile == nil || len(account.Posts) != 3 || len(account.Labels) != 2 {
+			t.Errorf("expected profile, 3 posts and 2 labels of %s, got %+v", account.Email, account)
+		}
+		for _, post := range account.Posts {
+			if len(post.Comments) != 2 {
+				t.Errorf("expected 2 comments of %s, got %d", post.Title, len(post.Comments))
+			}
+		}
+	}
+}
+
+func TestFactory_ReuseAssociation(t *testing.T) {
+	Clear()
+	db := setupAccountDB(t)
+
+	companies, accounts := newAccountFactories()
+	existing, err := companies.Create(db, 2)
+	if err != nil {
+		t.Fatalf("failed to create companies: %v", err)
+	}
+
+	created, err := accounts.
+		Association("Company", Reuse(existing...), 1).
+		Association("Posts", Reuse[testPost](), 0).
+		Create(db, 4)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if count := countModelRows(t, db, &testCompany{}); count != 2 {
+		t.Errorf("expected the 2 existing companies only, got %d", count)
+	}
+	if count := countModelRows(t, db, &testPost{}); count != 0 {
+		t.Errorf("expected the posts count to be replaced by 0, got %d", count)
+	}
+	for i, account := range created {
+		if account.CompanyID != existing[i%2].ID {
+			t.Errorf("expected account %d to reuse company %d, got %d", i, existing[i%2].ID, account.CompanyID)
+		}
+	}
+}
+
+func TestFactory_AssociationPanicsOnInvalidField(t *testing.T) {
+	_, accounts := newAccountFactories()
+	labels := NewFactory(func(seq int) testLabel { return testLabel{} })
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"Missing", "has no exported field Missing"},
+		{"Posts", "cannot hold gorm_seed.testLabel"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if err, ok := r.(error); !ok || !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("expected panic containing %q, got %v", tt.want, r)
+				}
+			}()
+			accounts.Association(tt.field, labels, 1)
+		})
+	}
+}
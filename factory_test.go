@@ -0,0 +1,128 @@
+package gorm_seed
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// testUser is a model built by factories in tests
+type testUser struct {
+	ID    uint
+	Email string `gorm:"uniqueIndex"`
+	Role  string
+	Admin bool
+}
+
+func newUserFactory() *Factory[testUser] {
+	return NewFactory(func(seq int) testUser {
+		return testUser{Email: fmt.Sprintf("user%d@example.com", seq), Role: "member"}
+	})
+}
+
+var admin Trait[testUser] = func(u *testUser) {
+	u.Admin = true
+	u.Role = "admin"
+}
+
+func TestFactory_BuildSequenceAndTraits(t *testing.T) {
+	users := newUserFactory()
+
+	members := users.Build(2)
+	admins := users.With(admin).Build(1)
+	owner := users.With(admin, func(u *testUser) { u.Role = "owner" }).BuildOne()
+
+	if len(members) != 2 || members[0].Email != "user1@example.com" || members[1].Email != "user2@example.com" {
+		t.Errorf("unexpected members %+v", members)
+	}
+	if members[0].Admin || members[0].Role != "member" {
+		t.Errorf("expected traits not to apply to the base factory, got %+v", members[0])
+	}
+
+	// Derived factories share the sequence
+	if admins[0].Email != "user3@example.com" || !admins[0].Admin || admins[0].Role != "admin" {
+		t.Errorf("unexpected admin %+v", admins[0])
+	}
+	if owner.Email != "user4@example.com" || !owner.Admin || owner.Role != "owner" {
+		t.Errorf("expected traits to apply in order, got %+v", owner)
+	}
+	if users.Sequence() != 4 {
+		t.Errorf("expected sequence 4, got %d", users.Sequence())
+	}
+
+	users.ResetSequence()
+	if user := users.BuildOne(); user.Email != "user1@example.com" {
+		t.Errorf("expected sequence to restart, got %s", user.Email)
+	}
+}
+
+func TestFactory_Create(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&testUser{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	users := newUserFactory()
+	Register(&mockSeeder{name: "001_users", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		if _, err := users.Create(db, 250); err != nil {
+			return err
+		}
+		_, err := users.With(admin).CreateOne(db)
+		return err
+	}})
+
+	if err := RunAll(db, nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	var count, admins int64
+	db.Model(&testUser{}).Count(&count)
+	db.Model(&testUser{}).Where("admin = ?", true).Count(&admins)
+	if count != 251 || admins != 1 {
+		t.Errorf("expected 251 users with 1 admin, got %d users and %d admins", count, admins)
+	}
+
+	created, err := users.Create(db, 2)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if created[0].ID == 0 || created[1].ID != created[0].ID+1 {
+		t.Errorf("expected primary keys to be assigned, got %+v", created)
+	}
+
+	if none, err := users.Create(db, 0); err != nil || len(none) != 0 {
+		t.Errorf("expected no users and no error, got %v, %v", none, err)
+	}
+}
+
+func TestFactory_ConcurrentSequence(t *testing.T) {
+	users := newUserFactory()
+
+	var wg sync.WaitGroup
+	emails := make(chan string, 100)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, user := range users.Build(10) {
+				emails <- user.Email
+			}
+		}()
+	}
+	wg.Wait()
+	close(emails)
+
+	seen := make(map[string]bool)
+	for email := range emails {
+		if seen[email] {
+			t.Fatalf("duplicate email %s", email)
+		}
+		seen[email] = true
+	}
+	if len(seen) != 100 {
+		t.Errorf("expected 100 unique emails, got %d", len(seen))
+	}
+}
@@ -0,0 +1,46 @@
+package fake
+
+// The word lists are part of the output: changing them changes the data
+// generated for a seed.
+
+var firstNames = []string{
+	"Ada", "Alan", "Amara", "Anika", "Ben", "Carla", "Chen", "Clara", "Daniel", "Diego",
+	"Elena", "Emil", "Fatima", "Felix", "Grace", "Hana", "Hugo", "Ines", "Isaac", "Jonas",
+	"Julia", "Kai", "Lena", "Leo", "Lucia", "Mara", "Mateo", "Maya", "Nadia", "Noah",
+	"Olivia", "Omar", "Priya", "Rafael", "Rosa", "Sam", "Sofia", "Theo", "Yara", "Zoe",
+}
+
+var lastNames = []string{
+	"Alvarez", "Bauer", "Becker", "Brooks", "Carter", "Castillo", "Dubois", "Ellis", "Fischer", "Garcia",
+	"Hartley", "Hayes", "Ito", "Jensen", "Kim", "Kowalski", "Larsen", "Lovelace", "Moreau", "Nakamura",
+	"Novak", "Okafor", "Olsen", "Patel", "Quinn", "Rossi", "Santos", "Schmidt", "Silva", "Turner",
+}
+
+var emailDomains = []string{"example.com", "example.net", "example.org"}
+
+var streetNames = []string{
+	"Maple", "Oak", "Cedar", "Elm", "Willow", "Lake", "Hill", "Park", "River", "Station",
+	"Church", "Mill", "Garden", "Forest", "Harbor", "Meadow", "Spring", "Sunset", "Valley", "Bridge",
+}
+
+var streetSuffixes = []string{"Street", "Avenue", "Road", "Lane", "Drive", "Way", "Court", "Place"}
+
+var cities = []string{
+	"Amsterdam", "Austin", "Barcelona", "Berlin", "Boston", "Copenhagen", "Dublin", "Edinburgh", "Helsinki", "Lisbon",
+	"Lyon", "Melbourne", "Montreal", "Munich", "Oslo", "Porto", "Seattle", "Stockholm", "Toronto", "Vienna",
+}
+
+var countries = []string{
+	"Australia", "Austria", "Canada", "Denmark", "Finland", "France", "Germany", "Ireland", "Netherlands", "Norway",
+	"Portugal", "Spain", "Sweden", "United Kingdom", "United States",
+}
+
+var companySuffixes = []string{"Group", "Labs", "Logistics", "Partners", "Systems", "Trading", "Works"}
+
+var loremWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+	"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim",
+	"ad", "minim", "veniam", "quis", "nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip",
+	"ex", "ea", "commodo", "consequat", "duis", "aute", "irure", "in", "reprehenderit", "voluptate",
+	"velit", "esse", "cillum", "fugiat", "nulla", "pariatur", "excepteur", "sint", "occaecat", "cupidatat",
+}
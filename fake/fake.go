@@ -0,0 +1,204 @@
+// Package fake generates realistic looking demo data from a seedable random
+// number generator. A Faker created with the same seed returns the same
+// values in the same order on every machine.
+package fake
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Faker generates fake data. It is safe for concurrent use, but values are
+// only reproducible when they are requested in the same order.
+type Faker struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// New creates a faker whose values are determined by seed
+func New(seed int64) *Faker {
+	return &Faker{rng: rand.New(rand.NewSource(seed))}
+}
+
+// Int returns a number between min and max, both included
+func (f *Faker) Int(min, max int) int {
+	if max <= min {
+		return min
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return min + f.rng.Intn(max-min+1)
+}
+
+// Float returns a number between min, included, and max
+func (f *Faker) Float(min, max float64) float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return min + f.rng.Float64()*(max-min)
+}
+
+// Bool returns true or false with equal probability
+func (f *Faker) Bool() bool {
+	return f.Int(0, 1) == 1
+}
+
+// Pick returns one of the values
+func (f *Faker) Pick(values ...string) string {
+	return Pick(f, values)
+}
+
+// Pick returns one of the values, or the zero value when there are none
+func Pick[T any](f *Faker, values []T) T {
+	if len(values) == 0 {
+		var zero T
+		return zero
+	}
+	return values[f.Int(0, len(values)-1)]
+}
+
+// Digits returns a string of n random digits
+func (f *Faker) Digits(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteByte(byte('0' + f.Int(0, 9)))
+	}
+	return b.String()
+}
+
+// FirstName returns a first name
+func (f *Faker) FirstName() string {
+	return Pick(f, firstNames)
+}
+
+// LastName returns a last name
+func (f *Faker) LastName() string {
+	return Pick(f, lastNames)
+}
+
+// Name returns a first and last name
+func (f *Faker) Name() string {
+	return f.FirstName() + " " + f.LastName()
+}
+
+// Username returns a lowercase user name like "ada.lovelace42"
+func (f *Faker) Username() string {
+	return strings.ToLower(f.FirstName()+"."+f.LastName()) + f.Digits(2)
+}
+
+// Email returns an address at one of the domains reserved for examples
+func (f *Faker) Email() string {
+	return f.Username() + "@" + Pick(f, emailDomains)
+}
+
+// Phone returns a North American number in the 555 range used for fiction,
+// like "+1-415-555-0142"
+func (f *Faker) Phone() string {
+	return fmt.Sprintf("+1-%d-555-01%s", f.Int(201, 989), f.Digits(2))
+}
+
+// Address is a postal address
+type Address struct {
+	Street     string
+	City       string
+	PostalCode string
+	Country    string
+}
+
+// String formats the address on a single line
+func (a Address) String() string {
+	return fmt.Sprintf("%s, %s %s, %s", a.Street, a.PostalCode, a.City, a.Country)
+}
+
+// StreetAddress returns a house number and street like "42 Maple Street"
+func (f *Faker) StreetAddress() string {
+	return fmt.Sprintf("%d %s %s", f.Int(1, 999), Pick(f, streetNames), Pick(f, streetSuffixes))
+}
+
+// City returns a city name
+func (f *Faker) City() string {
+	return Pick(f, cities)
+}
+
+// PostalCode returns a five digit postal code
+func (f *Faker) PostalCode() string {
+	return fmt.Sprintf("%d%s", f.Int(1, 9), f.Digits(4))
+}
+
+// Country returns a country name
+func (f *Faker) Country() string {
+	return Pick(f, countries)
+}
+
+// Address returns a full postal address
+func (f *Faker) Address() Address {
+	return Address{
+		Street:     f.StreetAddress(),
+		City:       f.City(),
+		PostalCode: f.PostalCode(),
+		Country:    f.Country(),
+	}
+}
+
+// Company returns a company name like "Hartley Logistics"
+func (f *Faker) Company() string {
+	return f.LastName() + " " + Pick(f, companySuffixes)
+}
+
+// Word returns a lorem ipsum word
+func (f *Faker) Word() string {
+	return Pick(f, loremWords)
+}
+
+// Words returns n lorem ipsum words
+func (f *Faker) Words(n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = f.Word()
+	}
+	return words
+}
+
+// Sentence returns a lorem ipsum sentence of 6 to 12 words
+func (f *Faker) Sentence() string {
+	sentence := strings.Join(f.Words(f.Int(6, 12)), " ")
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
+
+// Paragraph returns 3 to 6 lorem ipsum sentences
+func (f *Faker) Paragraph() string {
+	sentences := make([]string, f.Int(3, 6))
+	for i := range sentences {
+		sentences[i] = f.Sentence()
+	}
+	return strings.Join(sentences, " ")
+}
+
+// Date returns a time between from, included, and to, in UTC with second
+// precision. Pass fixed bounds, not time.Now, for reproducible dates.
+func (f *Faker) Date(from, to time.Time) time.Time {
+	from, to = from.UTC().Truncate(time.Second), to.UTC().Truncate(time.Second)
+	seconds := int64(to.Sub(from) / time.Second)
+	if seconds <= 0 {
+		return from
+	}
+
+	f.mu.Lock()
+	offset := f.rng.Int63n(seconds)
+	f.mu.Unlock()
+	return from.Add(time.Duration(offset) * time.Second)
+}
+
+// UUID returns a random version 4 UUID
+func (f *Faker) UUID() string {
+	b := make([]byte, 16)
+	f.mu.Lock()
+	f.rng.Read(b)
+	f.mu.Unlock()
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
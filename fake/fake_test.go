@@ -0,0 +1,91 @@
+package fake
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sample generates one value of every kind
+func sample(f *Faker) []string {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []string{
+		f.Name(), f.Email(), f.Phone(), f.Address().String(), f.Company(),
+		f.Paragraph(), f.Date(from, to).Format(time.RFC3339), f.UUID(),
+	}
+}
+
+func TestNew_SameSeedSameData(t *testing.T) {
+	first := strings.Join(sample(New(42)), "\n")
+	second := strings.Join(sample(New(42)), "\n")
+	if first != second {
+		t.Errorf("expected identical data for the same seed:\n%s\n---\n%s", first, second)
+	}
+
+	if other := strings.Join(sample(New(43)), "\n"); other == first {
+		t.Error("expected different data for another seed")
+	}
+}
+
+func TestNew_StableAcrossVersions(t *testing.T) {
+	// Changing the generators or word lists changes these values; that breaks
+	// reproducing data of earlier runs and should be a deliberate decision
+	f := New(42)
+	got := []string{
+		f.Name(),
+		f.Email(),
+		f.Phone(),
+		f.Date(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).Format(time.RFC3339),
+	}
+	expected := []string{"Mara Lovelace", "nadia.alvarez35@example.com", "+1-713-555-0183", "2021-11-22T03:41:54Z"}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestFaker_Formats(t *testing.T) {
+	f := New(1)
+	patterns := map[string]*regexp.Regexp{
+		f.Email():         regexp.MustCompile(`^[a-z]+\.[a-z]+\d{2}@example\.(com|net|org)$`),
+		f.Phone():         regexp.MustCompile(`^\+1-\d{3}-555-01\d{2}$`),
+		f.PostalCode():    regexp.MustCompile(`^[1-9]\d{4}$`),
+		f.StreetAddress(): regexp.MustCompile(`^\d{1,3} [A-Z][a-z]+ [A-Z][a-z]+$`),
+		f.Sentence():      regexp.MustCompile(`^[A-Z][a-z]*( [a-z]+){5,11}\.$`),
+		f.UUID():          regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`),
+	}
+	for value, pattern := range patterns {
+		if !pattern.MatchString(value) {
+			t.Errorf("expected %q to match %s", value, pattern)
+		}
+	}
+}
+
+func TestFaker_Ranges(t *testing.T) {
+	f := New(7)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(48 * time.Hour)
+
+	for i := 0; i < 1000; i++ {
+		if n := f.Int(3, 5); n < 3 || n > 5 {
+			t.Fatalf("Int out of range: %d", n)
+		}
+		if x := f.Float(1, 2); x < 1 || x >= 2 {
+			t.Fatalf("Float out of range: %f", x)
+		}
+		if d := f.Date(from, to); d.Before(from) || !d.Before(to) {
+			t.Fatalf("Date out of range: %s", d)
+		}
+	}
+
+	if n := f.Int(5, 5); n != 5 {
+		t.Errorf("expected 5, got %d", n)
+	}
+	if d := f.Date(to, from); !d.Equal(to) {
+		t.Errorf("expected empty range to return from, got %s", d)
+	}
+	if v := Pick(f, []int{}); v != 0 {
+		t.Errorf("expected zero value from empty Pick, got %d", v)
+	}
+}
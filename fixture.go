@@ -0,0 +1,381 @@
+package gorm_seed
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// FixtureModel maps the model name used by fixture files to a GORM model
+type FixtureModel struct {
+	// Name identifies the model in fixture files, e.g. "countries"
+	Name string
+	// Model is a pointer to a zero value of the model, e.g. &Country{}
+	Model interface{}
+	// Keys are the fields or columns identifying an existing row, e.g.
+	// "Code". Rows whose keys match an existing row are not inserted again.
+	// Without keys every row is inserted.
+	Keys []string
+}
+
+// fixtureDocument is the object form of a YAML or JSON fixture file
+type fixtureDocument struct {
+	Model string
+	Keys  []string
+	Rows  []map[string]interface{}
+}
+
+// fixtureSeeder inserts the rows of a fixture file
+type fixtureSeeder struct {
+	name      string
+	file      string
+	model     FixtureModel
+	keys      []string
+	rows      []*fixtureRow
+	set       *fixtureSet
+	dependsOn []string
+}
+
+func (s *fixtureSeeder) Name() string {
+	return s.name
+}
+
+// DependsOn returns the fixtures holding the rows this fixture references
+func (s *fixtureSeeder) DependsOn() []string {
+	return s.dependsOn
+}
+
+// Seed inserts each row, skipping rows whose keys match an existing row
+func (s *fixtureSeeder) Seed(db *gorm.DB, deps map[string]interface{}) error {
+	stmt, err := s.parse(db)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range s.rows {
+		record, err := s.record(db, stmt, row)
+		if err != nil {
+			return err
+		}
+
+		if len(s.keys) == 0 {
+			if err := db.Create(record.Interface()).Error; err != nil {
+				return fmt.Errorf("%s row %d: %w", s.file, row.index, err)
+			}
+		} else {
+			conds, err := s.keyConditions(stmt, record)
+			if err != nil {
+				return err
+			}
+			if err := db.Where(conds).FirstOrCreate(record.Interface()).Error; err != nil {
+				return fmt.Errorf("%s row %d: %w", s.file, row.index, err)
+			}
+		}
+		s.set.inserted(db, row, record)
+	}
+	return nil
+}
+
+// parse parses the fixture's model with the naming strategy of db
+func (s *fixtureSeeder) parse(db *gorm.DB) (*gorm.Statement, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(s.model.Model); err != nil {
+		return nil, fmt.Errorf("failed to parse model %s: %w", s.model.Name, err)
+	}
+	return stmt, nil
+}
+
+// record builds a new model value from the columns and references of row
+func (s *fixtureSeeder) record(db *gorm.DB, stmt *gorm.Statement, row *fixtureRow) (reflect.Value, error) {
+	record := reflect.New(stmt.Schema.ModelType)
+	for column, value := range row.values {
+		if value == nil {
+			continue
+		}
+		field := stmt.Schema.LookUpField(column)
+		if field == nil {
+			return record, fmt.Errorf("%s row %d: unknown column %s of model %s", s.file, row.index, column, s.model.Name)
+		}
+		if err := field.Set(stmt.Context, record.Elem(), value); err != nil {
+			return record, fmt.Errorf("%s row %d: invalid value for %s: %w", s.file, row.index, column, err)
+		}
+	}
+
+	for _, ref := range row.refs {
+		if err := s.set.resolve(db, stmt, ref, record); err != nil {
+			return record, fmt.Errorf("%s row %d: %w", s.file, row.index, err)
+		}
+	}
+	return record, nil
+}
+
+// keyConditions returns the key columns of record with their values
+func (s *fixtureSeeder) keyConditions(stmt *gorm.Statement, record reflect.Value) (map[string]interface{}, error) {
+	conds := make(map[string]interface{}, len(s.keys))
+	for _, key := range s.keys {
+		field := stmt.Schema.LookUpField(key)
+		if field == nil {
+			return nil, fmt.Errorf("%s: unknown key %s of model %s", s.file, key, s.model.Name)
+		}
+		conds[field.DBName], _ = field.ValueOf(stmt.Context, record.Elem())
+	}
+	return conds, nil
+}
+
+// find loads the existing row matching the keys of a row that was not
+// inserted in this run, e.g. because its fixture ran in an earlier run
+func (s *fixtureSeeder) find(db *gorm.DB, row *fixtureRow) (reflect.Value, error) {
+	if len(s.keys) == 0 && db.DryRun {
+		// A dry run inserts nothing, so there is no key to find
+		return reflect.New(reflect.TypeOf(s.model.Model).Elem()), nil
+	}
+	if len(s.keys) == 0 {
+		return reflect.Value{}, fmt.Errorf("row %q of %s was not inserted in this run and the model has no keys to find it", row.label, s.file)
+	}
+
+	stmt, err := s.parse(db)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	record, err := s.record(db, stmt, row)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	conds, err := s.keyConditions(stmt, record)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	found := reflect.New(stmt.Schema.ModelType)
+	if err := db.Where(conds).First(found.Interface()).Error; err != nil {
+		return reflect.Value{}, fmt.Errorf("failed to find row %q of %s: %w", row.label, s.file, err)
+	}
+	return found, nil
+}
+
+// fixtureSequencePattern matches the sequence or timestamp prefix of a
+// fixture file name
+var fixtureSequencePattern = regexp.MustCompile(`^(\d{3}|\d{14})_`)
+
+// LoadFixtures reads the fixture files matching pattern from fsys, e.g.
+// os.DirFS("fixtures") or an embed.FS, and returns a seeder per file.
+//
+// The seeder is named after the file without its extension, so
+// "001_countries.yaml" becomes "001_countries". Its rows belong to the model
+// named after the file without the sequence prefix ("countries"), unless a
+// YAML or JSON file names another model. YAML and JSON files hold a list of
+// rows, or an object with "model", "keys" and "rows". CSV files hold a header
+// of columns followed by the rows; empty cells are left unset.
+//
+// A row can be labeled with a "_label" column, and rows of any file loaded
+// in the same call can reference it through a belongs-to association of
+// their model, e.g. "customer: alice" sets CustomerID to the primary key of
+// the customer labeled alice. Fixtures depend on the fixtures they reference,
+// so those are inserted first. A reference to a label that no file defines
+// returns ErrUnknownFixtureLabel.
+func LoadFixtures(fsys fs.FS, pattern string, models ...FixtureModel) ([]Seeder, error) {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fixture pattern %q: %w", pattern, err)
+	}
+
+	byName := make(map[string]FixtureModel, len(models))
+	for _, model := range models {
+		byName[model.Name] = model
+	}
+
+	fixtures := make([]*fixtureSeeder, 0, len(files))
+	for _, file := range files {
+		fixture, err := loadFixture(fsys, file, byName)
+		if err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, fixture)
+	}
+
+	if err := linkFixtures(fixtures); err != nil {
+		return nil, err
+	}
+
+	seeders := make([]Seeder, 0, len(fixtures))
+	for _, fixture := range fixtures {
+		seeders = append(seeders, fixture)
+	}
+	return seeders, nil
+}
+
+// loadFixture reads a single fixture file
+func loadFixture(fsys fs.FS, file string, models map[string]FixtureModel) (*fixtureSeeder, error) {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture %s: %w", file, err)
+	}
+
+	ext := path.Ext(file)
+	name := strings.TrimSuffix(path.Base(file), ext)
+
+	var doc fixtureDocument
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		var raw interface{}
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", file, err)
+		}
+		doc, err = fixtureDocumentOf(raw)
+	case ".json":
+		var raw interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", file, err)
+		}
+		doc, err = fixtureDocumentOf(raw)
+	case ".csv":
+		doc.Rows, err = csvRows(data)
+	default:
+		return nil, fmt.Errorf("unsupported fixture format %s of %s", ext, file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", file, err)
+	}
+
+	if doc.Model == "" {
+		doc.Model = fixtureSequencePattern.ReplaceAllString(name, "")
+	}
+	model, ok := models[doc.Model]
+	if !ok {
+		return nil, fmt.Errorf("fixture %s: unknown model %s", file, doc.Model)
+	}
+	if doc.Keys == nil {
+		doc.Keys = model.Keys
+	}
+
+	fixture := &fixtureSeeder{
+		name:  name,
+		file:  file,
+		model: model,
+		keys:  doc.Keys,
+		rows:  make([]*fixtureRow, 0, len(doc.Rows)),
+	}
+	for i, values := range doc.Rows {
+		row := &fixtureRow{
+			fixture: fixture,
+			index:   i + 1,
+			values:  values,
+		}
+		if label, ok := values[fixtureLabelColumn]; ok {
+			row.label = fmt.Sprint(label)
+			delete(values, fixtureLabelColumn)
+		}
+		fixture.rows = append(fixture.rows, row)
+	}
+	return fixture, nil
+}
+
+// fixtureDocumentOf converts a decoded YAML or JSON fixture into a document
+func fixtureDocumentOf(raw interface{}) (fixtureDocument, error) {
+	var doc fixtureDocument
+	switch value := raw.(type) {
+	case nil:
+		return doc, nil
+	case []interface{}:
+		rows, err := fixtureRows(value)
+		doc.Rows = rows
+		return doc, err
+	case map[string]interface{}:
+		for key, field := range value {
+			switch key {
+			case "model":
+				model, ok := field.(string)
+				if !ok {
+					return doc, fmt.Errorf("model must be a string")
+				}
+				doc.Model = model
+			case "keys":
+				keys, ok := field.([]interface{})
+				if !ok {
+					return doc, fmt.Errorf("keys must be a list")
+				}
+				doc.Keys = make([]string, 0, len(keys))
+				for _, key := range keys {
+					doc.Keys = append(doc.Keys, fmt.Sprint(key))
+				}
+			case "rows":
+				rows, ok := field.([]interface{})
+				if !ok {
+					return doc, fmt.Errorf("rows must be a list")
+				}
+				var err error
+				if doc.Rows, err = fixtureRows(rows); err != nil {
+					return doc, err
+				}
+			default:
+				return doc, fmt.Errorf("unknown field %s, expected model, keys or rows", key)
+			}
+		}
+		return doc, nil
+	default:
+		return doc, fmt.Errorf("expected a list of rows or an object with rows")
+	}
+}
+
+// fixtureRows converts decoded rows into column maps
+func fixtureRows(values []interface{}) ([]map[string]interface{}, error) {
+	rows := make([]map[string]interface{}, 0, len(values))
+	for i, value := range values {
+		row, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("row %d is not an object", i+1)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// csvRows reads CSV rows keyed by the columns of the header
+func csvRows(data []byte) ([]map[string]interface{}, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	rows := make([]map[string]interface{}, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]interface{}, len(header))
+		for i, column := range header {
+			if record[i] != "" {
+				row[strings.TrimSpace(column)] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// RegisterFixtures loads fixture files into the registry, see LoadFixtures
+func (r *Registry) RegisterFixtures(fsys fs.FS, pattern string, models ...FixtureModel) error {
+	seeders, err := LoadFixtures(fsys, pattern, models...)
+	if err != nil {
+		return err
+	}
+	for _, seeder := range seeders {
+		r.Register(seeder)
+	}
+	return nil
+}
+
+// RegisterFixtures loads fixture files into the default registry, see LoadFixtures
+func RegisterFixtures(fsys fs.FS, pattern string, models ...FixtureModel) error {
+	return registry.RegisterFixtures(fsys, pattern, models...)
+}
@@ -0,0 +1,211 @@
+package gorm_seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// ErrUnknownFixtureLabel is returned for a fixture row referencing a label
+// that no loaded fixture defines
+var ErrUnknownFixtureLabel = errors.New("unknown fixture label")
+
+// fixtureLabelColumn names the column holding the label of a fixture row
+const fixtureLabelColumn = "_label"
+
+// fixtureRow is a row of a fixture file
+type fixtureRow struct {
+	fixture *fixtureSeeder
+	// index is the 1-based position of the row in its file
+	index  int
+	label  string
+	values map[string]interface{}
+	refs   []fixtureReference
+}
+
+// fixtureReference points a belongs-to association of a row at a labeled row
+type fixtureReference struct {
+	relation string
+	target   *fixtureRow
+}
+
+// fixtureSet holds the labeled rows of fixtures loaded together, per model
+type fixtureSet struct {
+	labels map[reflect.Type]map[string]*fixtureRow
+}
+
+// fixtureRecordsKey is the context key of the labeled records inserted in a run
+type fixtureRecordsKey struct{}
+
+// fixtureRecords holds the records of labeled rows inserted in a single run.
+// Records are only valid within the run that inserted them: a later run may
+// see them rolled back, or never inserted when the run was a dry run.
+type fixtureRecords struct {
+	mu      sync.Mutex
+	records map[*fixtureRow]reflect.Value
+}
+
+// withFixtureRecords returns a context carrying an empty set of records for a run
+func withFixtureRecords(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fixtureRecordsKey{}, &fixtureRecords{records: make(map[*fixtureRow]reflect.Value)})
+}
+
+// fixtureRecordsOf returns the records of the run db belongs to, or nil
+// outside a run
+func fixtureRecordsOf(db *gorm.DB) *fixtureRecords {
+	if db == nil || db.Statement == nil || db.Statement.Context == nil {
+		return nil
+	}
+	records, _ := db.Statement.Context.Value(fixtureRecordsKey{}).(*fixtureRecords)
+	return records
+}
+
+// linkFixtures indexes the labeled rows of the fixtures and turns columns
+// naming a belongs-to association into references, e.g. "customer: alice".
+// A fixture depends on the other fixtures it references, so they load first.
+func linkFixtures(fixtures []*fixtureSeeder) error {
+	set := &fixtureSet{labels: make(map[reflect.Type]map[string]*fixtureRow)}
+	cache := &sync.Map{}
+	schemas := make(map[*fixtureSeeder]*schema.Schema, len(fixtures))
+
+	for _, fixture := range fixtures {
+		fixture.set = set
+		modelSchema, err := schema.Parse(fixture.model.Model, cache, schema.NamingStrategy{})
+		if err != nil {
+			return fmt.Errorf("failed to parse model %s: %w", fixture.model.Name, err)
+		}
+		schemas[fixture] = modelSchema
+
+		labels := set.labels[modelSchema.ModelType]
+		if labels == nil {
+			labels = make(map[string]*fixtureRow)
+			set.labels[modelSchema.ModelType] = labels
+		}
+		for _, row := range fixture.rows {
+			if row.label == "" {
+				continue
+			}
+			if existing, ok := labels[row.label]; ok {
+				return fmt.Errorf("duplicate label %q of model %s in %s row %d and %s row %d",
+					row.label, fixture.model.Name, existing.fixture.file, existing.index, fixture.file, row.index)
+			}
+			labels[row.label] = row
+		}
+	}
+
+	for _, fixture := range fixtures {
+		modelSchema := schemas[fixture]
+		dependsOn := make(map[string]bool)
+
+		for _, row := range fixture.rows {
+			for column, value := range row.values {
+				if modelSchema.LookUpField(column) != nil {
+					continue
+				}
+				relation := belongsTo(modelSchema, column)
+				if relation == nil {
+					continue
+				}
+				delete(row.values, column)
+				if value == nil {
+					continue
+				}
+
+				label := fmt.Sprint(value)
+				target := set.labels[relation.FieldSchema.ModelType][label]
+				if target == nil {
+					return fmt.Errorf("%w: %s row %d references %q of %s", ErrUnknownFixtureLabel, fixture.file, row.index, label, relation.FieldSchema.Name)
+				}
+				if target.fixture == fixture && target.index >= row.index {
+					return fmt.Errorf("%s row %d references %q, which is not defined before it", fixture.file, row.index, label)
+				}
+				if target.fixture != fixture {
+					dependsOn[target.fixture.name] = true
+				}
+
+				row.refs = append(row.refs, fixtureReference{relation: relation.Name, target: target})
+			}
+		}
+
+		for name := range dependsOn {
+			fixture.dependsOn = append(fixture.dependsOn, name)
+		}
+		sort.Strings(fixture.dependsOn)
+	}
+	return nil
+}
+
+// belongsTo finds the belongs-to association named by a fixture column, e.g.
+// "customer" or "billing_address"
+func belongsTo(modelSchema *schema.Schema, column string) *schema.Relationship {
+	name := strings.ReplaceAll(column, "_", "")
+	for _, relation := range modelSchema.Relationships.BelongsTo {
+		if strings.EqualFold(relation.Name, name) {
+			return relation
+		}
+	}
+	return nil
+}
+
+// resolve sets the foreign key of ref's association on record to the
+// referenced row
+func (set *fixtureSet) resolve(db *gorm.DB, stmt *gorm.Statement, ref fixtureReference, record reflect.Value) error {
+	target, err := set.record(db, ref.target)
+	if err != nil {
+		return err
+	}
+
+	relation := stmt.Schema.Relationships.Relations[ref.relation]
+	for _, reference := range relation.References {
+		if reference.OwnPrimaryKey || reference.PrimaryKey == nil {
+			continue
+		}
+		value, _ := reference.PrimaryKey.ValueOf(stmt.Context, target.Elem())
+		if err := reference.ForeignKey.Set(stmt.Context, record.Elem(), value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", reference.ForeignKey.Name, err)
+		}
+	}
+	return nil
+}
+
+// record returns the inserted record of a labeled row, finding it by its
+// keys when its fixture did not run earlier in this run
+func (set *fixtureSet) record(db *gorm.DB, row *fixtureRow) (reflect.Value, error) {
+	if records := fixtureRecordsOf(db); records != nil {
+		records.mu.Lock()
+		record, ok := records.records[row]
+		records.mu.Unlock()
+		if ok {
+			return record, nil
+		}
+	}
+
+	record, err := row.fixture.find(db, row)
+	if err != nil {
+		return record, err
+	}
+	set.inserted(db, row, record)
+	return record, nil
+}
+
+// inserted remembers the record of a labeled row for the rest of the run.
+// Records of a dry run are not remembered, as nothing was inserted.
+func (set *fixtureSet) inserted(db *gorm.DB, row *fixtureRow, record reflect.Value) {
+	if row.label == "" || db.DryRun {
+		return
+	}
+	records := fixtureRecordsOf(db)
+	if records == nil {
+		return
+	}
+	records.mu.Lock()
+	defer records.mu.Unlock()
+	records.records[row] = record
+}
@@ -0,0 +1,231 @@
+package gorm_seed
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"gorm.io/gorm"
+)
+
+// testCustomer and testOrder are models referencing each other in fixtures
+type testCustomer struct {
+	ID    uint
+	Email string `gorm:"uniqueIndex"`
+}
+
+type testOrder struct {
+	ID         uint
+	Number     string
+	CustomerID uint
+	Customer   testCustomer
+}
+
+// testCategory references itself through its parent
+type testCategory struct {
+	ID       uint
+	Name     string
+	ParentID *uint
+	Parent   *testCategory
+}
+
+var orderFixtureModels = []FixtureModel{
+	{Name: "customers", Model: &testCustomer{}, Keys: []string{"Email"}},
+	{Name: "orders", Model: &testOrder{}},
+	{Name: "categories", Model: &testCategory{}},
+}
+
+func setupOrderDB(t *testing.T) *gorm.DB {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&testCustomer{}, &testOrder{}, &testCategory{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+// customerEmails maps each order number to the email of its customer
+func customerEmails(t *testing.T, db *gorm.DB) map[string]string {
+	var orders []testOrder
+	if err := db.Preload("Customer").Find(&orders).Error; err != nil {
+		t.Fatalf("failed to read orders: %v", err)
+	}
+	emails := make(map[string]string, len(orders))
+	for _, order := range orders {
+		emails[order.Number] = order.Customer.Email
+	}
+	return emails
+}
+
+func TestRegisterFixtures_LabelReferences(t *testing.T) {
+	Clear()
+	db := setupOrderDB(t)
+
+	// Orders sort before customers by name, the reference loads customers first
+	files := fstest.MapFS{
+		"001_orders.yaml": {Data: []byte(`
+- {number: A-1, customer: bob}
+- {number: A-2, customer: alice}
+`)},
+		"002_orders.csv":     {Data: []byte("number,customer\nB-1,alice\n")},
+		"003_customers.yaml": {Data: []byte("- {_label: alice, email: alice@example.com}\n- {_label: bob, email: bob@example.com}\n")},
+	}
+	if err := RegisterFixtures(files, "*", orderFixtureModels...); err != nil {
+		t.Fatalf("failed to register fixtures: %v", err)
+	}
+
+	orders, _ := GetByName("001_orders")
+	if deps := dependenciesOf(orders); len(deps) != 1 || deps[0] != "003_customers" {
+		t.Errorf("expected 001_orders to depend on 003_customers, got %v", deps)
+	}
+
+	if err := RunAll(db, nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	emails := customerEmails(t, db)
+	expected := map[string]string{"A-1": "bob@example.com", "A-2": "alice@example.com", "B-1": "alice@example.com"}
+	for number, email := range expected {
+		if emails[number] != email {
+			t.Errorf("expected order %s of %s, got %q", number, email, emails[number])
+		}
+	}
+}
+
+func TestRegisterFixtures_LabelFoundByKeys(t *testing.T) {
+	Clear()
+	db := setupOrderDB(t)
+
+	customers := fstest.MapFS{
+		"001_customers.yaml": {Data: []byte("- {_label: alice, email: alice@example.com}\n")},
+	}
+	if err := RegisterFixtures(customers, "*", orderFixtureModels...); err != nil {
+		t.Fatalf("failed to register fixtures: %v", err)
+	}
+	opts := RunOptions{SkipExecuted: true}
+	if err := RunAllWithOptions(db, nil, opts); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// The customers fixture is skipped in the second run, alice is found by email
+	Clear()
+	customers["002_orders.yaml"] = &fstest.MapFile{Data: []byte("- {number: A-1, customer: alice}\n")}
+	if err := RegisterFixtures(customers, "*", orderFixtureModels...); err != nil {
+		t.Fatalf("failed to register fixtures: %v", err)
+	}
+	if err := RunAllWithOptions(db, nil, opts); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if email := customerEmails(t, db)["A-1"]; email != "alice@example.com" {
+		t.Errorf("expected order of alice, got %q", email)
+	}
+}
+
+func TestRegisterFixtures_LabelNotKeptFromDryRun(t *testing.T) {
+	Clear()
+	db := setupOrderDB(t)
+	if err := db.Create(&testCustomer{Email: "alice@example.com"}).Error; err != nil {
+		t.Fatalf("failed to insert customer: %v", err)
+	}
+
+	files := fstest.MapFS{
+		"001_customers.yaml": {Data: []byte("- {_label: alice, email: alice@example.com}\n")},
+		"002_orders.yaml":    {Data: []byte("- {number: A-1, customer: alice}\n")},
+	}
+	if err := RegisterFixtures(files, "*", orderFixtureModels...); err != nil {
+		t.Fatalf("failed to register fixtures: %v", err)
+	}
+
+	if _, err := DryRunAll(db, nil, RunOptions{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// The dry run inserted nothing, alice is found by email in the real run
+	if err := RunSpecific("002_orders", db, nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if email := customerEmails(t, db)["A-1"]; email != "alice@example.com" {
+		t.Errorf("expected order of alice, got %q", email)
+	}
+}
+
+func TestRegisterFixtures_SelfReference(t *testing.T) {
+	Clear()
+	db := setupOrderDB(t)
+
+	files := fstest.MapFS{
+		"001_categories.yaml": {Data: []byte(`
+- {_label: books, name: Books}
+- {name: Novels, parent: books}
+`)},
+	}
+	if err := RegisterFixtures(files, "*", orderFixtureModels...); err != nil {
+		t.Fatalf("failed to register fixtures: %v", err)
+	}
+	if err := RunAll(db, nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	var novels testCategory
+	if err := db.Preload("Parent").Where("name = ?", "Novels").First(&novels).Error; err != nil {
+		t.Fatalf("failed to read category: %v", err)
+	}
+	if novels.Parent == nil || novels.Parent.Name != "Books" {
+		t.Errorf("expected Novels to belong to Books, got %+v", novels.Parent)
+	}
+}
+
+func TestLoadFixtures_LabelErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+		want  string
+	}{
+		{
+			name: "dangling label",
+			files: fstest.MapFS{
+				"001_customers.yaml": {Data: []byte("- {_label: alice, email: alice@example.com}\n")},
+				"002_orders.yaml":    {Data: []byte("- {number: A-1, customer: carol}\n")},
+			},
+			want: `002_orders.yaml row 1 references "carol"`,
+		},
+		{
+			name: "label of another model",
+			files: fstest.MapFS{
+				"001_categories.yaml": {Data: []byte("- {_label: alice, name: Books}\n")},
+				"002_orders.yaml":     {Data: []byte("- {number: A-1, customer: alice}\n")},
+			},
+			want: `references "alice"`,
+		},
+		{
+			name: "duplicate label",
+			files: fstest.MapFS{
+				"001_customers.yaml": {Data: []byte("- {_label: alice, email: a@example.com}\n")},
+				"002_customers.yaml": {Data: []byte("- {_label: alice, email: b@example.com}\n")},
+			},
+			want: `duplicate label "alice"`,
+		},
+		{
+			name: "forward reference",
+			files: fstest.MapFS{
+				"001_categories.yaml": {Data: []byte("- {name: Novels, parent: books}\n- {_label: books, name: Books}\n")},
+			},
+			want: "not defined before it",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFixtures(tt.files, "*", orderFixtureModels...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	_, err := LoadFixtures(tests[0].files, "*", orderFixtureModels...)
+	if !errors.Is(err, ErrUnknownFixtureLabel) {
+		t.Errorf("expected ErrUnknownFixtureLabel, got %v", err)
+	}
+}
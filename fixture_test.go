@@ -0,0 +1,169 @@
+package gorm_seed
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// testCountry is a model loaded from fixtures in tests
+type testCountry struct {
+	ID         uint
+	Code       string `gorm:"uniqueIndex"`
+	Name       string
+	Population int
+	EU         bool
+	JoinedAt   *time.Time
+}
+
+var countryFixture = FixtureModel{Name: "countries", Model: &testCountry{}, Keys: []string{"Code"}}
+
+func loadCountries(t *testing.T, files fstest.MapFS) []testCountry {
+	t.Helper()
+	Clear()
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&testCountry{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	if err := RegisterFixtures(files, "fixtures/*", countryFixture); err != nil {
+		t.Fatalf("failed to register fixtures: %v", err)
+	}
+	if err := RunAll(db, nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	var countries []testCountry
+	if err := db.Order("code").Find(&countries).Error; err != nil {
+		t.Fatalf("failed to read countries: %v", err)
+	}
+	return countries
+}
+
+func TestRegisterFixtures_YAML(t *testing.T) {
+	countries := loadCountries(t, fstest.MapFS{
+		"fixtures/001_countries.yaml": {Data: []byte(`
+- code: DE
+  name: Germany
+  population: 83200000
+  eu: true
+  joined_at: 1958-01-01T00:00:00Z
+- Code: NO
+  Name: Norway
+`)},
+	})
+
+	if len(countries) != 2 {
+		t.Fatalf("expected 2 countries, got %d", len(countries))
+	}
+	de := countries[0]
+	if de.Name != "Germany" || de.Population != 83200000 || !de.EU || de.JoinedAt == nil || de.JoinedAt.Year() != 1958 {
+		t.Errorf("unexpected row %+v", de)
+	}
+	if countries[1].Name != "Norway" || countries[1].EU {
+		t.Errorf("unexpected row %+v", countries[1])
+	}
+
+	if _, err := GetByName("001_countries"); err != nil {
+		t.Errorf("expected seeder named after the file, got %v", err)
+	}
+}
+
+func TestRegisterFixtures_JSONAndCSV(t *testing.T) {
+	countries := loadCountries(t, fstest.MapFS{
+		"fixtures/001_eu.json": {Data: []byte(`{
+			"model": "countries",
+			"rows": [{"code": "FR", "name": "France", "population": 68000000, "eu": true}]
+		}`)},
+		"fixtures/002_countries.csv": {Data: []byte("code,name,population,eu\nIS,Iceland,380000,false\nCH,Switzerland,,\n")},
+	})
+
+	var names []string
+	for _, country := range countries {
+		names = append(names, country.Name)
+	}
+	if strings.Join(names, ",") != "Switzerland,France,Iceland" {
+		t.Fatalf("unexpected countries %v", names)
+	}
+	if countries[1].Population != 68000000 || !countries[1].EU {
+		t.Errorf("unexpected JSON row %+v", countries[1])
+	}
+	if countries[2].Population != 380000 || countries[0].Population != 0 {
+		t.Errorf("unexpected CSV rows %+v", countries)
+	}
+}
+
+func TestRegisterFixtures_KeysSkipExistingRows(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&testCountry{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	db.Create(&testCountry{Code: "DE", Name: "Deutschland"})
+
+	files := fstest.MapFS{
+		"fixtures/001_countries.yaml": {Data: []byte("- {code: DE, name: Germany}\n- {code: AT, name: Austria}\n")},
+	}
+	if err := RegisterFixtures(files, "fixtures/*.yaml", countryFixture); err != nil {
+		t.Fatalf("failed to register fixtures: %v", err)
+	}
+
+	// Running twice inserts each row once
+	for i := 0; i < 2; i++ {
+		if err := RunAll(db, nil); err != nil {
+			t.Fatalf("run %d: expected no error, got: %v", i+1, err)
+		}
+	}
+
+	var countries []testCountry
+	db.Order("id").Find(&countries)
+	if len(countries) != 2 || countries[0].Name != "Deutschland" || countries[1].Name != "Austria" {
+		t.Errorf("expected existing DE and a single AT, got %+v", countries)
+	}
+}
+
+func TestLoadFixtures_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want string
+	}{
+		{"unknown model", "001_cities.yaml", "- {name: Berlin}", "unknown model cities"},
+		{"unknown format", "001_countries.xml", "<countries/>", "unsupported fixture format"},
+		{"invalid yaml", "001_countries.yaml", "- [", "failed to parse fixture"},
+		{"unknown field", "001_countries.json", `{"table": "countries"}`, "unknown field table"},
+		{"row not an object", "001_countries.json", `["DE"]`, "row 1 is not an object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := fstest.MapFS{tt.file: {Data: []byte(tt.data)}}
+			_, err := LoadFixtures(files, "*", countryFixture)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestRegisterFixtures_UnknownColumn(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&testCountry{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	files := fstest.MapFS{
+		"001_countries.yaml": {Data: []byte("- {code: DE, capital: Berlin}\n")},
+	}
+	if err := RegisterFixtures(files, "*.yaml", countryFixture); err != nil {
+		t.Fatalf("failed to register fixtures: %v", err)
+	}
+
+	err := RunAll(db, nil)
+	if err == nil || !strings.Contains(err.Error(), "unknown column capital") {
+		t.Errorf("expected unknown column error, got %v", err)
+	}
+}
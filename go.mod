@@ -3,6 +3,7 @@ module github.com/lunar-kiln/gorm-seed
 go 1.21
 
 require (
+	gopkg.in/yaml.v3 v3.0.1
 	gorm.io/driver/sqlite v1.6.0
 	gorm.io/gorm v1.30.0
 )
@@ -0,0 +1,191 @@
+package gorm_seed
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultHistoryTable is the table used to record seeder runs
+const DefaultHistoryTable = "seed_history"
+
+// Statuses recorded for a seeder run in the history table
+const (
+	StatusSuccess    = "success"
+	StatusFailed     = "failed"
+	StatusRolledBack = "rolled_back"
+)
+
+// SeedHistory is a single seeder run recorded in the history table
+type SeedHistory struct {
+	ID         uint          `gorm:"primaryKey"`
+	SeederName string        `gorm:"size:255;not null;index"`
+	RunAt      time.Time     `gorm:"not null"`
+	Duration   time.Duration `gorm:"not null"`
+	Status     string        `gorm:"size:20;not null"`
+	Error      string        `gorm:"type:text"`
+	// Version is the version of a VersionedSeeder at the time of the run
+	Version string `gorm:"size:255"`
+}
+
+// TableName returns the default history table name
+func (SeedHistory) TableName() string {
+	return DefaultHistoryTable
+}
+
+// seedHistory reads and writes the history table for a single run
+type seedHistory struct {
+	db    *gorm.DB
+	table string
+	// dryRun keeps the history read-only
+	dryRun bool
+
+	// mu guards executed and versions, which are shared by copies made with withDB
+	mu       *sync.Mutex
+	executed map[string]bool
+	// versions holds the version of the latest successful run of each seeder
+	versions map[string]string
+	// failures holds the failed runs recorded through this copy, so they can
+	// be recorded again when its transaction is rolled back
+	failures []SeedHistory
+}
+
+// newSeedHistory prepares the history table when the options ask for it.
+// It returns nil when history tracking is disabled.
+func newSeedHistory(db *gorm.DB, opts RunOptions) (*seedHistory, error) {
+	if !opts.TrackHistory && !opts.SkipExecuted {
+		return nil, nil
+	}
+
+	table := opts.HistoryTable
+	if table == "" {
+		table = DefaultHistoryTable
+	}
+
+	h := &seedHistory{
+		db:       db,
+		table:    table,
+		dryRun:   opts.DryRun,
+		mu:       &sync.Mutex{},
+		executed: make(map[string]bool),
+		versions: make(map[string]string),
+	}
+
+	if opts.DryRun {
+		// A dry run reads an existing history but never creates one
+		if !db.Migrator().HasTable(table) {
+			return h, nil
+		}
+	} else if err := db.Table(table).AutoMigrate(&SeedHistory{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate history table %s: %w", table, err)
+	}
+
+	var records []SeedHistory
+	if err := db.Table(table).Select("seeder_name", "status", "version").Order("id").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to load history from %s: %w", table, err)
+	}
+	// The latest record of each seeder decides whether it counts as executed
+	for _, record := range records {
+		h.executed[record.SeederName] = record.Status == StatusSuccess
+		if record.Status == StatusSuccess {
+			h.versions[record.SeederName] = record.Version
+		}
+	}
+
+	return h, nil
+}
+
+// withDB returns a copy of the history that writes through db
+func (h *seedHistory) withDB(db *gorm.DB) *seedHistory {
+	if h == nil {
+		return nil
+	}
+	return &seedHistory{
+		db:       db,
+		table:    h.table,
+		dryRun:   h.dryRun,
+		mu:       h.mu,
+		executed: h.executed,
+		versions: h.versions,
+	}
+}
+
+// isExecuted reports whether the latest recorded run of the seeder succeeded
+func (h *seedHistory) isExecuted(name string) bool {
+	if h == nil {
+		return false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.executed[name]
+}
+
+// executedVersion returns the version of the seeder's last successful run,
+// and whether that run is also its latest one
+func (h *seedHistory) executedVersion(name string) (string, bool) {
+	if h == nil {
+		return "", false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.versions[name], h.executed[name]
+}
+
+// record stores the outcome of a seeder run. status is recorded when the run
+// succeeded, StatusFailed otherwise.
+func (h *seedHistory) record(name, version string, startedAt time.Time, status string, runErr error) error {
+	if h == nil || h.dryRun {
+		return nil
+	}
+
+	entry := SeedHistory{
+		SeederName: name,
+		RunAt:      startedAt,
+		Duration:   time.Since(startedAt),
+		Status:     status,
+		Version:    version,
+	}
+	if runErr != nil {
+		entry.Status = StatusFailed
+		entry.Error = runErr.Error()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if entry.Status == StatusFailed {
+		h.failures = append(h.failures, entry)
+	}
+	if err := h.db.Table(h.table).Create(&entry).Error; err != nil {
+		return fmt.Errorf("failed to record history for %s: %w", name, err)
+	}
+
+	h.executed[name] = entry.Status == StatusSuccess
+	if entry.Status == StatusSuccess {
+		h.versions[name] = version
+	}
+	return nil
+}
+
+// recordFailures stores the failed runs recorded through rolledBack, a copy
+// writing through a transaction that was rolled back, so a failed
+// all-or-nothing run still leaves its failures in the history
+func (h *seedHistory) recordFailures(rolledBack *seedHistory) error {
+	if h == nil || h.dryRun {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, entry := range rolledBack.failures {
+		if err := h.db.Table(h.table).Create(&entry).Error; err != nil {
+			return fmt.Errorf("failed to record history for %s: %w", entry.SeederName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package gorm_seed
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestRunAllWithOptions_TrackHistory(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	Register(&mockSeeder{name: "001_first"})
+	Register(&mockSeeder{
+		name: "002_failing",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			return errors.New("intentional failure")
+		},
+	})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		ContinueOnError: true,
+		TrackHistory:    true,
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	var records []SeedHistory
+	if err := db.Order("id").Find(&records).Error; err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 history records, got %d", len(records))
+	}
+	if records[0].SeederName != "001_first" || records[0].Status != StatusSuccess {
+		t.Errorf("unexpected first record: %+v", records[0])
+	}
+	if records[1].SeederName != "002_failing" || records[1].Status != StatusFailed {
+		t.Errorf("unexpected second record: %+v", records[1])
+	}
+	if records[1].Error != "intentional failure" {
+		t.Errorf("expected error text to be recorded, got '%s'", records[1].Error)
+	}
+}
+
+func TestRunAllWithOptions_SkipExecuted(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	runs := map[string]int{}
+	fail := true
+
+	Register(&mockSeeder{
+		name: "001_once",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			runs["001_once"]++
+			return nil
+		},
+	})
+	Register(&mockSeeder{
+		name: "002_flaky",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			runs["002_flaky"]++
+			if fail {
+				return errors.New("flaky")
+			}
+			return nil
+		},
+	})
+
+	skipped := []string{}
+	opts := RunOptions{
+		ContinueOnError: true,
+		SkipExecuted:    true,
+		OnSeederSkip: func(name string) {
+			skipped = append(skipped, name)
+		},
+	}
+
+	if err := RunAllWithOptions(db, nil, opts); err == nil {
+		t.Fatal("expected error on first run, got nil")
+	}
+
+	fail = false
+	if err := RunAllWithOptions(db, nil, opts); err != nil {
+		t.Fatalf("expected no error on second run, got: %v", err)
+	}
+
+	if err := RunAllWithOptions(db, nil, opts); err != nil {
+		t.Fatalf("expected no error on third run, got: %v", err)
+	}
+
+	if runs["001_once"] != 1 {
+		t.Errorf("expected 001_once to run once, ran %d times", runs["001_once"])
+	}
+	if runs["002_flaky"] != 2 {
+		t.Errorf("expected 002_flaky to run until it succeeded (2 times), ran %d times", runs["002_flaky"])
+	}
+
+	expectedSkipped := []string{"001_once", "001_once", "002_flaky"}
+	if len(skipped) != len(expectedSkipped) {
+		t.Fatalf("expected skipped %v, got %v", expectedSkipped, skipped)
+	}
+	for i, name := range expectedSkipped {
+		if skipped[i] != name {
+			t.Errorf("expected skipped %v, got %v", expectedSkipped, skipped)
+			break
+		}
+	}
+}
+
+func TestRunAllWithOptions_CustomHistoryTable(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	Register(&mockSeeder{name: "001_first"})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		TrackHistory: true,
+		HistoryTable: "custom_seed_runs",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if db.Migrator().HasTable(DefaultHistoryTable) {
+		t.Errorf("expected default history table not to be created")
+	}
+
+	var count int64
+	if err := db.Table("custom_seed_runs").Count(&count).Error; err != nil {
+		t.Fatalf("failed to count history: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 history record, got %d", count)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -37,6 +38,9 @@ func CreateSeeder(opts CreateOptions) (string, error) {
 
 	// Clean the name (remove .go extension and any existing prefix)
 	name := cleanSeederName(opts.Name)
+	if name == "" {
+		return "", fmt.Errorf("seeder name %q has no letters or digits", opts.Name)
+	}
 
 	// Generate prefix based on mode
 	var prefix string
@@ -96,7 +100,13 @@ func CreateSeeder(opts CreateOptions) (string, error) {
 	return filePath, nil
 }
 
-// cleanSeederName removes .go extension and any existing numeric/timestamp prefix
+// invalidNameChars matches the runs of characters that are not allowed in a
+// seeder name or Go identifier
+var invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// cleanSeederName removes .go extension and any existing numeric/timestamp
+// prefix, and replaces characters other than letters, digits and underscores
+// with an underscore, so "user-roles" becomes "user_roles"
 func cleanSeederName(name string) string {
 	// Remove .go extension if present
 	name = strings.TrimSuffix(name, ".go")
@@ -108,12 +118,13 @@ func cleanSeederName(name string) string {
 		// Check if it's a timestamp (14 digits) or sequence (3 digits)
 		if len(first) == 14 || len(first) == 3 {
 			if isNumeric(first) {
-				return parts[1]
+				name = parts[1]
 			}
 		}
 	}
 
-	return name
+	name = invalidNameChars.ReplaceAllString(name, "_")
+	return strings.Trim(name, "_")
 }
 
 // isNumeric checks if a string contains only digits
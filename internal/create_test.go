@@ -3,8 +3,11 @@ package internal
 import (
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+
+	gorm_seed "github.com/lunar-kiln/gorm-seed"
 )
 
 func TestCreateSeeder_Sequential(t *testing.T) {
@@ -160,6 +163,10 @@ func TestCreateSeeder_CleanName(t *testing.T) {
 		{"20240101120000_users.go", "users"},
 		{"my_custom_seeder", "my_custom_seeder"},
 		{"001_my_custom_seeder.go", "my_custom_seeder"},
+		{"user-roles", "user_roles"},
+		{"001_user-roles.go", "user_roles"},
+		{"user roles", "user_roles"},
+		{"-users-", "users"},
 	}
 
 	for _, tt := range tests {
@@ -261,6 +268,39 @@ func TestCreateSeeder_EmptyName(t *testing.T) {
 	}
 }
 
+func TestCreateSeeder_NameWithoutLettersOrDigits(t *testing.T) {
+	_, err := CreateSeeder(CreateOptions{Name: "--", Dir: t.TempDir(), Sequential: true})
+	if err == nil {
+		t.Error("expected error for name without letters or digits, got nil")
+	}
+}
+
+func TestCreateSeeder_NamePassesValidation(t *testing.T) {
+	namePattern := regexp.MustCompile(`Name\(\) string \{\s*return "([^"]+)"`)
+
+	for _, sequential := range []bool{true, false} {
+		filename, err := CreateSeeder(CreateOptions{Name: "user-roles", Dir: t.TempDir(), Sequential: sequential})
+		if err != nil {
+			t.Fatalf("CreateSeeder failed: %v", err)
+		}
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("failed to read file: %v", err)
+		}
+
+		match := namePattern.FindSubmatch(content)
+		if match == nil {
+			t.Fatalf("expected a Name method in:\n%s", content)
+		}
+		if err := gorm_seed.ValidateName(string(match[1])); err != nil {
+			t.Errorf("expected generated name to be valid, got: %v", err)
+		}
+		if !strings.Contains(string(content), "type UserRolesSeeder struct") {
+			t.Errorf("expected struct UserRolesSeeder, got:\n%s", content)
+		}
+	}
+}
+
 func TestCreateSeeder_EmptyDir(t *testing.T) {
 	opts := CreateOptions{
 		Name:       "test",
@@ -61,15 +61,21 @@ func generateMainGoTemplate(packageName string) string {
 	return `package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
 
 	gorm_seed "github.com/lunar-kiln/gorm-seed"
 	"gorm.io/gorm"
 
-	_ "` + packageName + `/query"
+	"` + packageName + `/query"
 )
 
 var (
@@ -77,19 +83,40 @@ var (
 	runSeeder   = flag.String("run", "", "Run a specific seeder by name")
 	listSeeders = flag.Bool("list", false, "List all available seeders")
 	continueOnError = flag.Bool("continue", false, "Continue running even if a seeder fails")
+	forceRun        = flag.Bool("force", false, "Re-run seeders that already ran successfully")
+	withDeps        = flag.Bool("with-deps", false, "Run the seeder's dependencies first (used with --run)")
+	seederTimeout   = flag.Duration("timeout", 0, "Maximum duration of each seeder (e.g., 30s)")
+	rollbackTarget  = flag.String("rollback", "", "Roll back a seeder by name, the last N seeders, or all")
+	includeTags     = flag.String("tags", "", "Only run seeders with one of these comma-separated tags")
+	excludeTags     = flag.String("exclude-tags", "", "Skip seeders with one of these comma-separated tags")
+	fromSeeder      = flag.String("from", "", "Only run the named seeder and those after it")
+	toSeeder        = flag.String("to", "", "Only run the named seeder and those before it")
+	onlySeeders     = flag.String("only", "", "Only run seeders matching one of these comma-separated globs or /regexps/")
+	exceptSeeders   = flag.String("except", "", "Skip seeders matching one of these comma-separated globs or /regexps/")
+	dryRun          = flag.Bool("dry-run", false, "Print the SQL the seeders would execute without running it")
+	reportTarget    = flag.String("report", "", "Write a run report, e.g. junit:seed-report.xml or json:seed-report.json")
+	randomSeed      = flag.Int64("random-seed", 0, "Seed of the fake data generator, 0 picks a random seed")
 )
 
 func main() {
 	flag.Parse()
 
 	// Check if at least one command is provided
-	if !*runAll && *runSeeder == "" && !*listSeeders {
+	if !*runAll && *runSeeder == "" && !*listSeeders && *rollbackTarget == "" {
 		printUsage()
 		os.Exit(1)
 	}
 
+	// Reject an invalid report target before anything runs
+	if *reportTarget != "" {
+		if _, _, err := parseReportTarget(*reportTarget); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	// Initialize database
 	db, deps := query.InitDatabases()
+	databases := query.InitConnections()
 
 	// Handle list command
 	if *listSeeders {
@@ -97,17 +124,37 @@ func main() {
 		return
 	}
 
+	// Cancel the running seeder on Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	// Handle run commands
-	if *runAll {
-		handleRunAll(db, deps)
+	if *rollbackTarget != "" {
+		handleRollback(*rollbackTarget, db, databases, deps)
+	} else if *runAll {
+		handleRunAll(ctx, db, databases, deps)
 	} else if *runSeeder != "" {
-		handleRunSpecific(*runSeeder, db, deps)
+		handleRunSpecific(ctx, *runSeeder, db, databases, deps)
 	}
 }
 
 func handleList() {
-	seeders := gorm_seed.GetAll()
+	seeders, err := gorm_seed.NewRunner(nil, gorm_seed.RunOptions{
+		From:        *fromSeeder,
+		To:          *toSeeder,
+		Only:        splitList(*onlySeeders),
+		Except:      splitList(*exceptSeeders),
+		IncludeTags: splitList(*includeTags),
+		ExcludeTags: splitList(*excludeTags),
+	}).Selection()
+	if err != nil {
+		log.Fatal("Invalid seeder selection: ", err)
+	}
 
+	if len(seeders) == 0 && gorm_seed.DefaultRegistry().Count() > 0 {
+		fmt.Println("No seeders match the selection")
+		return
+	}
 	if len(seeders) == 0 {
 		fmt.Println("No seeders registered")
 		fmt.Printf("\nNote: Make sure to import your seeders package.\n")
@@ -118,33 +165,46 @@ func handleList() {
 	fmt.Printf("Available Seeders (%d)\n", len(seeders))
 	fmt.Println("========================================")
 	for i, seeder := range seeders {
-		fmt.Printf("%d. %s\n", i+1, seeder.Name())
+		fmt.Printf("%d. %s", i+1, seeder.Name())
+		if tagged, ok := seeder.(gorm_seed.TaggedSeeder); ok && len(tagged.Tags()) > 0 {
+			fmt.Printf(" [%s]", strings.Join(tagged.Tags(), ", "))
+		}
+		if dependent, ok := seeder.(gorm_seed.DependentSeeder); ok && len(dependent.DependsOn()) > 0 {
+			fmt.Printf(" (depends on: %s)", strings.Join(dependent.DependsOn(), ", "))
+		}
+		if database, ok := seeder.(gorm_seed.DatabaseSeeder); ok && database.Database() != "" {
+			fmt.Printf(" (database: %s)", database.Database())
+		}
+		fmt.Println()
 	}
 	fmt.Println("========================================")
 }
 
-func handleRunAll(db interface{}, deps map[string]interface{}) {
+func handleRunAll(ctx context.Context, db interface{}, databases map[string]*gorm.DB, deps map[string]interface{}) {
 	fmt.Println("========================================")
-	fmt.Println("Running All Seeders")
+	fmt.Println("Running All Seeders" + dryRunSuffix())
 	fmt.Println("========================================")
 
-	var err error
-	if *continueOnError {
-		err = gorm_seed.RunAllWithOptions(db.(*gorm.DB), deps, gorm_seed.RunOptions{
-			ContinueOnError: true,
-			OnSeederStart: func(name string) {
-				fmt.Printf("→ Starting: %s\n", name)
-			},
-			OnSeederComplete: func(name string) {
-				fmt.Printf("✓ Completed: %s\n", name)
-			},
-			OnSeederError: func(name string, err error) {
-				fmt.Printf("✗ Failed: %s - %v\n", name, err)
-			},
-		})
-	} else {
-		err = gorm_seed.RunAll(db.(*gorm.DB), deps)
-	}
+	report := gorm_seed.NewReport()
+	err := gorm_seed.RunAllContext(ctx, db.(*gorm.DB), deps, gorm_seed.RunOptions{
+		ContinueOnError: *continueOnError,
+		DryRun:          *dryRun,
+		OnSeederSQL:     printSQL,
+		SeederTimeout:   *seederTimeout,
+		RandomSeed:      *randomSeed,
+		Databases:       databases,
+		IncludeTags:     splitList(*includeTags),
+		ExcludeTags:     splitList(*excludeTags),
+		From:            *fromSeeder,
+		To:              *toSeeder,
+		Only:            splitList(*onlySeeders),
+		Except:          splitList(*exceptSeeders),
+		TrackHistory:    true,
+		SkipExecuted:    !*forceRun,
+		Observers:       []gorm_seed.Observer{gorm_seed.ObserverFunc(printEvent), report},
+	})
+	writeReport(report)
+	printRandomSeed(report)
 
 	if err != nil {
 		fmt.Println("========================================")
@@ -164,16 +224,35 @@ func handleRunAll(db interface{}, deps map[string]interface{}) {
 	}
 
 	fmt.Println("========================================")
-	fmt.Println("✓ All seeders completed successfully")
+	fmt.Println("✓ All seeders completed successfully" + dryRunSuffix())
 	fmt.Println("========================================")
 }
 
-func handleRunSpecific(name string, db interface{}, deps map[string]interface{}) {
+func handleRunSpecific(ctx context.Context, name string, db interface{}, databases map[string]*gorm.DB, deps map[string]interface{}) {
 	fmt.Println("========================================")
-	fmt.Printf("Running Seeder: %s\n", name)
+	fmt.Printf("Running Seeder: %s%s\n", name, dryRunSuffix())
 	fmt.Println("========================================")
 
-	if err := gorm_seed.RunSpecific(name, db.(*gorm.DB), deps); err != nil {
+	report := gorm_seed.NewReport()
+	runner := gorm_seed.NewRunner(nil, gorm_seed.RunOptions{
+		DryRun:       *dryRun,
+		OnSeederSQL:  printSQL,
+		RandomSeed:   *randomSeed,
+		Databases:    databases,
+		TrackHistory: true,
+		Observers:    []gorm_seed.Observer{report},
+	})
+
+	var err error
+	if *withDeps {
+		err = runner.RunWithDependencies(ctx, name, db.(*gorm.DB), deps)
+	} else {
+		err = runner.RunSpecific(ctx, name, db.(*gorm.DB), deps)
+	}
+	writeReport(report)
+	printRandomSeed(report)
+
+	if err != nil {
 		fmt.Println("========================================")
 		fmt.Println("✗ Seeding failed")
 		fmt.Println("========================================")
@@ -181,24 +260,192 @@ func handleRunSpecific(name string, db interface{}, deps map[string]interface{})
 	}
 
 	fmt.Println("========================================")
-	fmt.Println("✓ Seeder completed successfully")
+	fmt.Println("✓ Seeder completed successfully" + dryRunSuffix())
+	fmt.Println("========================================")
+}
+
+func handleRollback(target string, db interface{}, databases map[string]*gorm.DB, deps map[string]interface{}) {
+	fmt.Println("========================================")
+	fmt.Printf("Rolling Back: %s\n", target)
+	fmt.Println("========================================")
+
+	opts := gorm_seed.RunOptions{
+		ContinueOnError: *continueOnError,
+		TrackHistory:    true,
+		Databases:       databases,
+		IncludeTags:     splitList(*includeTags),
+		ExcludeTags:     splitList(*excludeTags),
+		From:            *fromSeeder,
+		To:              *toSeeder,
+		Only:            splitList(*onlySeeders),
+		Except:          splitList(*exceptSeeders),
+		Observers:       []gorm_seed.Observer{gorm_seed.ObserverFunc(printEvent)},
+	}
+
+	var err error
+	if target == "all" {
+		err = gorm_seed.RollbackAll(db.(*gorm.DB), deps, opts)
+	} else if n, convErr := strconv.Atoi(target); convErr == nil {
+		err = gorm_seed.RollbackLast(n, db.(*gorm.DB), deps, opts)
+	} else {
+		err = gorm_seed.RollbackSpecific(target, db.(*gorm.DB), deps, opts)
+	}
+
+	if err != nil {
+		fmt.Println("========================================")
+		fmt.Println("✗ Rollback failed")
+		fmt.Println("========================================")
+		log.Fatal(err)
+	}
+
+	fmt.Println("========================================")
+	fmt.Println("✓ Rollback completed successfully")
 	fmt.Println("========================================")
 }
 
+// printEvent prints the progress of a run
+func printEvent(event gorm_seed.Event) {
+	starting, completed := "Starting", "Completed"
+	if event.Rollback {
+		starting, completed = "Rolling back", "Rolled back"
+	}
+
+	switch event.Type {
+	case gorm_seed.EventStart:
+		fmt.Printf("→ %s: %s\n", starting, event.SeederName)
+	case gorm_seed.EventFinish:
+		var rows int64
+		for _, n := range event.RowsAffected {
+			rows += n
+		}
+		fmt.Printf("✓ %s: %s (%s, %d rows)\n", completed, event.SeederName, event.Duration.Round(time.Millisecond), rows)
+	case gorm_seed.EventError:
+		fmt.Printf("✗ Failed: %s - %v\n", event.SeederName, event.Err)
+		var panicErr *gorm_seed.PanicError
+		if errors.As(event.Err, &panicErr) {
+			fmt.Printf("%s\n", panicErr.Stack)
+		}
+	case gorm_seed.EventRolledBack:
+		fmt.Println("↺ All changes rolled back")
+	case gorm_seed.EventSkip:
+		if event.Reason == gorm_seed.ReasonExecuted {
+			fmt.Printf("- Skipped: %s (%s, use --force to re-run)\n", event.SeederName, event.Reason)
+		} else {
+			fmt.Printf("- Skipped: %s (%s)\n", event.SeederName, event.Reason)
+		}
+	}
+}
+
+// printRandomSeed prints the seed of the fake data so the run can be reproduced
+func printRandomSeed(report *gorm_seed.Report) {
+	if report.RandomSeed != 0 {
+		fmt.Printf("Random seed: %d (use --random-seed=%d to reproduce)\n", report.RandomSeed, report.RandomSeed)
+	}
+}
+
+// parseReportTarget splits a --report value into format and file path
+func parseReportTarget(target string) (string, string, error) {
+	format, path, ok := strings.Cut(target, ":")
+	if !ok || path == "" || (format != "json" && format != "junit") {
+		return "", "", fmt.Errorf("invalid --report value %q, expected junit:<file> or json:<file>", target)
+	}
+	return format, path, nil
+}
+
+// writeReport writes the run report to the file given with --report
+func writeReport(report *gorm_seed.Report) {
+	if *reportTarget == "" {
+		return
+	}
+
+	format, path, err := parseReportTarget(*reportTarget)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		log.Fatal("Failed to create report: ", err)
+	}
+	if err := report.Write(format, file); err != nil {
+		file.Close()
+		log.Fatal("Failed to write report: ", err)
+	}
+	if err := file.Close(); err != nil {
+		log.Fatal("Failed to write report: ", err)
+	}
+	fmt.Printf("Report written to %s\n", path)
+}
+
+// printSQL prints the statements a seeder would execute in a dry run
+func printSQL(name string, statements []string) {
+	if len(statements) == 0 {
+		fmt.Println("    (no statements)")
+	}
+	for _, statement := range statements {
+		fmt.Printf("    %s;\n", statement)
+	}
+}
+
+// dryRunSuffix marks output of a dry run
+func dryRunSuffix() string {
+	if *dryRun {
+		return " (dry run, nothing was written)"
+	}
+	return ""
+}
+
+// splitList splits a comma-separated flag value, ignoring empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func printUsage() {
 	fmt.Println("Seeder CLI - Database Seeding Tool")
 	fmt.Println("\nUsage:")
 	fmt.Println("  go run . [flags]")
 	fmt.Println("\nFlags:")
-	fmt.Println("  --all          Run all seeders in order")
+	fmt.Println("  --all          Run all seeders that have not run yet")
 	fmt.Println("  --run=<name>   Run a specific seeder by name")
 	fmt.Println("  --list         List all available seeders")
 	fmt.Println("  --continue     Continue running even if a seeder fails")
+	fmt.Println("  --force        Re-run seeders that already ran (used with --all)")
+	fmt.Println("  --with-deps    Run the seeder's dependencies first (used with --run)")
+	fmt.Println("  --timeout=<d>  Maximum duration of each seeder, e.g. 30s (used with --all)")
+	fmt.Println("  --rollback=<x> Roll back a seeder by name, the last N seeders, or all")
+	fmt.Println("  --tags=<list>  Only run seeders with one of these tags, e.g. reference,demo")
+	fmt.Println("  --exclude-tags=<list>  Skip seeders with one of these tags")
+	fmt.Println("  --from=<name>  Only run the named seeder and those after it")
+	fmt.Println("  --to=<name>    Only run the named seeder and those before it")
+	fmt.Println("  --only=<list>  Only run seeders matching one of these globs or /regexps/")
+	fmt.Println("  --except=<list>  Skip seeders matching one of these globs or /regexps/")
+	fmt.Println("  --dry-run      Print the SQL without executing it (used with --all or --run)")
+	fmt.Println("  --report=<f:file>  Write a junit or json run report, e.g. junit:seed-report.xml")
+	fmt.Println("  --random-seed=<n>  Seed of the fake data generator, to reproduce a run's data")
 	fmt.Println("\nExamples:")
 	fmt.Println("  go run . --all")
 	fmt.Println("  go run . --run=001_users")
+	fmt.Println("  go run . --run=002_orders --with-deps")
 	fmt.Println("  go run . --list")
 	fmt.Println("  go run . --all --continue")
+	fmt.Println("  go run . --all --force")
+	fmt.Println("  go run . --all --timeout=2m")
+	fmt.Println("  go run . --rollback=001_users")
+	fmt.Println("  go run . --rollback=2")
+	fmt.Println("  go run . --rollback=all")
+	fmt.Println("  go run . --all --tags=reference,demo")
+	fmt.Println("  go run . --all --exclude-tags=load-test")
+	fmt.Println("  go run . --all --from=003_orders --to=005_invoices")
+	fmt.Println("  go run . --list --only='*_users' --except=001_admin_users")
+	fmt.Println("  go run . --all --dry-run")
+	fmt.Println("  go run . --all --report=junit:seed-report.xml")
+	fmt.Println("  go run . --all --random-seed=42")
 }
 `
 }
@@ -222,6 +469,9 @@ Edit ` + "`query/config.go`" + ` to configure your database connection:
 - MongoDB (optional)
 - Other dependencies (Casbin, etc.)
 
+Seeders for other databases return the connection name from ` + "`Database()`" + `.
+Open those connections in ` + "`InitConnections`" + ` under the same name.
+
 ## Usage
 
 ### List all seeders
@@ -239,11 +489,75 @@ go run . --all
 go run . --run=001_users
 ` + "```" + `
 
+### Run specific seeder with its dependencies
+` + "```bash" + `
+go run . --run=002_orders --with-deps
+` + "```" + `
+
 ### Continue on error
 ` + "```bash" + `
 go run . --all --continue
 ` + "```" + `
 
+### Limit how long each seeder may run
+` + "```bash" + `
+go run . --all --timeout=2m
+` + "```" + `
+
+Press Ctrl+C to cancel the running seeder; no further seeders are started.
+
+### Re-run seeders that already ran
+Every run is recorded in the ` + "`seed_history`" + ` table and ` + "`--all`" + ` skips
+seeders that already completed successfully. Use ` + "`--force`" + ` to run them again:
+` + "```bash" + `
+go run . --all --force
+` + "```" + `
+
+### Run seeders by tag
+Seeders that implement ` + "`Tags()`" + ` can be selected by group:
+` + "```bash" + `
+go run . --all --tags=reference,demo
+go run . --all --exclude-tags=load-test
+` + "```" + `
+
+### Run a range or pattern of seeders
+` + "`--from`" + ` and ` + "`--to`" + ` select a range of the execution order, ` + "`--only`" + ` and ` + "`--except`" + `
+select by name with globs or /regexps/. Add them to ` + "`--list`" + ` to preview the selection:
+` + "```bash" + `
+go run . --all --from=003_orders --to=005_invoices
+go run . --all --only='*_users' --except=001_admin_users
+go run . --list --only='/^00[1-3]_/'
+` + "```" + `
+
+### Reproduce fake data
+Seeders that generate data with ` + "`gorm_seed.Faker(db)`" + ` get the same values for the same seed.
+Each run prints its seed; pass it back to reproduce the data:
+` + "```bash" + `
+go run . --all --random-seed=42
+` + "```" + `
+
+### Preview the SQL
+` + "`--dry-run`" + ` prints the statements each seeder would execute without writing anything:
+` + "```bash" + `
+go run . --all --dry-run
+go run . --run=001_users --dry-run
+` + "```" + `
+
+### Write a run report
+` + "`--report`" + ` writes each seeder's status, duration and error as JUnit XML or JSON, e.g. for CI:
+` + "```bash" + `
+go run . --all --report=junit:seed-report.xml
+go run . --all --report=json:seed-report.json
+` + "```" + `
+
+### Roll back seeders
+Seeders that implement ` + "`Unseed`" + ` can be rolled back in reverse execution order:
+` + "```bash" + `
+go run . --rollback=001_users   # Roll back one seeder
+go run . --rollback=2           # Roll back the last 2 seeders that ran
+go run . --rollback=all         # Roll back every seeder that ran
+` + "```" + `
+
 ## Creating Seeders
 
 Use the gorm-seed CLI from your project root:
@@ -105,6 +105,28 @@ func TestGenerateMainGoTemplate(t *testing.T) {
 		"--run",
 		"--list",
 		"--continue",
+		"--force",
+		"--with-deps",
+		"--timeout",
+		"--rollback",
+		"handleRollback(",
+		"--tags",
+		"--exclude-tags",
+		"--dry-run",
+		"--from",
+		"--to",
+		"--only",
+		"--except",
+		".Selection()",
+		"query.InitConnections()",
+		"Databases: ",
+		"--report",
+		"--random-seed",
+		"RandomSeed: ",
+		"printRandomSeed(",
+		"writeReport(",
+		"DryRun: ",
+		"SkipExecuted: ",
 		"handleList()",
 		"handleRunAll(",
 		"handleRunSpecific(",
@@ -117,6 +139,25 @@ func TestGenerateMainGoTemplate(t *testing.T) {
 	}
 }
 
+func TestGenerateMainGoTemplate_HandlersTrackHistory(t *testing.T) {
+	content := generateMainGoTemplate("seeders")
+
+	// Every handler that runs seeders records them in the seed history
+	for _, handler := range []string{"handleRunAll(", "handleRunSpecific(", "handleRollback("} {
+		start := strings.Index(content, "func "+handler)
+		if start < 0 {
+			t.Fatalf("Generated main.go template missing handler: %s", handler)
+		}
+		body := content[start:]
+		if end := strings.Index(body[1:], "\nfunc "); end >= 0 {
+			body = body[:end+1]
+		}
+		if !strings.Contains(body, "TrackHistory:") {
+			t.Errorf("Expected %s to set TrackHistory", handler)
+		}
+	}
+}
+
 func TestGenerateConfigTemplate(t *testing.T) {
 	content := GenerateConfigTemplate("query", "")
 
@@ -124,6 +165,7 @@ func TestGenerateConfigTemplate(t *testing.T) {
 	expectedStrings := []string{
 		"package query",
 		"func InitDatabases()",
+		"func InitConnections() map[string]*gorm.DB",
 		"var db *gorm.DB",
 		"deps := make(map[string]interface{})",
 		"return db, deps",
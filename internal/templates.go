@@ -5,7 +5,7 @@ import "fmt"
 // GenerateConfigTemplate generates the config.go template content
 // dbType can be "postgresql", "mysql", or empty string for no database
 func GenerateConfigTemplate(packageName, dbType string) string {
-	var imports, dbCode string
+	var imports, dbCode, connCode string
 
 	switch dbType {
 	case "postgresql":
@@ -25,6 +25,12 @@ func GenerateConfigTemplate(packageName, dbType string) string {
 	}
 
 	fmt.Println("✓ Connected to PostgreSQL database")`
+		connCode = `	// Example: a second PostgreSQL database
+	// analytics, err := gorm.Open(postgres.Open("host=localhost user=postgres password=yourpassword dbname=analytics port=5432 sslmode=disable"), &gorm.Config{})
+	// if err != nil {
+	//     log.Fatal("Failed to connect to analytics database:", err)
+	// }
+	// databases["analytics"] = analytics`
 
 	case "mysql":
 		imports = `import (
@@ -43,6 +49,12 @@ func GenerateConfigTemplate(packageName, dbType string) string {
 	}
 
 	fmt.Println("✓ Connected to MySQL database")`
+		connCode = `	// Example: a second MySQL database
+	// analytics, err := gorm.Open(mysql.Open("user:password@tcp(127.0.0.1:3306)/analytics?charset=utf8mb4&parseTime=True&loc=Local"), &gorm.Config{})
+	// if err != nil {
+	//     log.Fatal("Failed to connect to analytics database:", err)
+	// }
+	// databases["analytics"] = analytics`
 
 	default:
 		imports = `import (
@@ -57,6 +69,12 @@ func GenerateConfigTemplate(packageName, dbType string) string {
 	// }
 	
 	var db *gorm.DB`
+		connCode = `	// Example: a second database
+	// analytics, err := gorm.Open(postgres.Open(analyticsDSN), &gorm.Config{})
+	// if err != nil {
+	//     log.Fatal("Failed to connect to analytics database:", err)
+	// }
+	// databases["analytics"] = analytics`
 	}
 
 	return fmt.Sprintf(`package %s
@@ -74,8 +92,22 @@ func InitDatabases() (*gorm.DB, map[string]interface{}) {
 	// Example: Add additional dependencies
 	// deps["redis"] = initRedis()
 	// deps["enforcer"] = initCasbin(db)
+	//
+	// Seeders can read these from the map, or implement SeedContainer and
+	// resolve them by type: gorm_seed.Resolve[*casbin.Enforcer](c)
 
 	return db, deps
 }
-`, packageName, imports, dbCode)
+
+// InitConnections initializes the named database connections used by
+// seeders that implement Database(), in addition to the main database
+// returned by InitDatabases
+func InitConnections() map[string]*gorm.DB {
+	databases := make(map[string]*gorm.DB)
+
+%s
+
+	return databases
+}
+`, packageName, imports, dbCode, connCode)
 }
@@ -0,0 +1,308 @@
+package gorm_seed
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ErrLockTimeout is returned when the run lock could not be acquired in time
+var ErrLockTimeout = errors.New("seed lock not acquired")
+
+const (
+	// DefaultLockName is the name of the run lock
+	DefaultLockName = "gorm_seed"
+	// DefaultLockTable is the table used for the run lock on databases
+	// without advisory locks
+	DefaultLockTable = "seed_lock"
+	// DefaultLockTimeout is how long a run waits for the lock
+	DefaultLockTimeout = time.Minute
+)
+
+var (
+	// lockPollInterval is how often a waiting run retries the lock
+	lockPollInterval = 100 * time.Millisecond
+	// lockExpiry is how long a lock row stays valid without being refreshed
+	// by its holder, so a crashed process does not block later runs forever
+	lockExpiry = time.Minute
+)
+
+// seedLock is the row holding the run lock in the lock table
+type seedLock struct {
+	Name       string    `gorm:"primaryKey;size:255"`
+	Owner      string    `gorm:"size:255;not null"`
+	AcquiredAt time.Time `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null"`
+}
+
+// LockError describes why a run could not acquire the run lock. It is
+// returned wrapped in a *SeederError without a SeederName, and matches
+// ErrLockTimeout when another run held the lock the whole time.
+type LockError struct {
+	// Name is the name of the lock, see RunOptions.LockName
+	Name string
+	Err  error
+}
+
+func (e *LockError) Error() string {
+	return fmt.Sprintf("lock %q: %v", e.Name, e.Err)
+}
+
+func (e *LockError) Unwrap() error {
+	return e.Err
+}
+
+// lockFailed reports a failure to lock as an error of the run, not of a seeder
+func lockFailed(name string, err error) error {
+	return &SeederError{Err: &LockError{Name: name, Err: err}}
+}
+
+// withLock runs fn while holding the run lock when opts ask for it, passing
+// the db the run should use. seeders is the selection of the run; an empty
+// selection does not lock.
+func withLock(ctx context.Context, db *gorm.DB, seeders []Seeder, opts RunOptions, fn func(db *gorm.DB) error) error {
+	if !opts.Lock || opts.DryRun || len(seeders) == 0 {
+		return fn(db)
+	}
+
+	name := opts.LockName
+	if name == "" {
+		name = DefaultLockName
+	}
+	timeout := opts.LockTimeout
+	if timeout <= 0 {
+		timeout = DefaultLockTimeout
+	}
+
+	switch db.Dialector.Name() {
+	case "postgres", "mysql":
+		if err := checkLockPool(db, opts); err != nil {
+			return lockFailed(name, err)
+		}
+		// Advisory locks belong to a database session, so the lock is taken
+		// and released on a single pinned connection
+		return db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+			release, err := acquireAdvisoryLock(ctx, conn, name, timeout)
+			if err != nil {
+				return lockFailed(name, err)
+			}
+			defer release()
+
+			// A sequential run uses the pinned connection, so it also works
+			// with a pool of a single connection. Parallel seeders cannot
+			// share a session and use the pool.
+			if opts.Concurrency > 1 {
+				return fn(db)
+			}
+			return fn(conn.Session(&gorm.Session{}))
+		})
+	default:
+		table := opts.LockTable
+		if table == "" {
+			table = DefaultLockTable
+		}
+		release, err := acquireTableLock(ctx, db, table, name, timeout)
+		if err != nil {
+			return lockFailed(name, err)
+		}
+		defer release()
+		return fn(db)
+	}
+}
+
+// checkLockPool fails when a parallel run would wait forever for a pool
+// connection, as the advisory lock keeps one pinned for the whole run
+func checkLockPool(db *gorm.DB, opts RunOptions) error {
+	if opts.Concurrency <= 1 {
+		return nil
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		// Not a *sql.DB pool, e.g. a prepared statement pool
+		return nil
+	}
+	if sqlDB.Stats().MaxOpenConnections == 1 {
+		return fmt.Errorf("parallel runs with Lock need more than one open connection, the lock pins one for the whole run")
+	}
+	return nil
+}
+
+// acquireAdvisoryLock takes a Postgres or MySQL advisory lock on conn
+func acquireAdvisoryLock(ctx context.Context, conn *gorm.DB, name string, timeout time.Duration) (func(), error) {
+	unlocked := conn.WithContext(context.Background())
+
+	if conn.Dialector.Name() == "mysql" {
+		// GET_LOCK waits on its own and returns 1 once the lock is taken
+		seconds := int(math.Ceil(timeout.Seconds()))
+		var acquired *int
+		if err := conn.WithContext(ctx).Raw("SELECT GET_LOCK(?, ?)", name, seconds).Scan(&acquired).Error; err != nil {
+			return nil, fmt.Errorf("failed to acquire: %w", err)
+		}
+		if acquired == nil || *acquired != 1 {
+			return nil, lockNotAcquired("another session", timeout, errLockDeadline)
+		}
+		return func() {
+			unlocked.Exec("SELECT RELEASE_LOCK(?)", name)
+		}, nil
+	}
+
+	key := lockKey(name)
+	deadline := time.Now().Add(timeout)
+	for {
+		var acquired bool
+		if err := conn.WithContext(ctx).Raw("SELECT pg_try_advisory_lock(?)", key).Scan(&acquired).Error; err != nil {
+			return nil, fmt.Errorf("failed to acquire: %w", err)
+		}
+		if acquired {
+			return func() {
+				unlocked.Exec("SELECT pg_advisory_unlock(?)", key)
+			}, nil
+		}
+
+		if err := waitForLock(ctx, deadline); err != nil {
+			return nil, lockNotAcquired("another session", timeout, err)
+		}
+	}
+}
+
+// acquireTableLock takes the lock by inserting its row into the lock table,
+// then keeps the row from expiring until the lock is released
+func acquireTableLock(ctx context.Context, db *gorm.DB, table, name string, timeout time.Duration) (func(), error) {
+	// Failed attempts are expected while waiting, so they are not logged
+	db = db.Session(&gorm.Session{
+		Context: context.Background(),
+		Logger:  db.Logger.LogMode(logger.Silent),
+	})
+	// Processes starting together race to create the table; the loser
+	// finds it on the second attempt
+	if err := db.Table(table).AutoMigrate(&seedLock{}); err != nil {
+		if err := db.Table(table).AutoMigrate(&seedLock{}); err != nil {
+			return nil, fmt.Errorf("failed to migrate lock table %s: %w", table, err)
+		}
+	}
+
+	owner, err := lockOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		now := time.Now()
+		// A lock whose holder stopped refreshing it has expired
+		if err := db.Table(table).Where("name = ? AND expires_at < ?", name, now).Delete(&seedLock{}).Error; err != nil {
+			return nil, fmt.Errorf("failed to clear expired lock: %w", err)
+		}
+
+		lock := seedLock{Name: name, Owner: owner, AcquiredAt: now, ExpiresAt: now.Add(lockExpiry)}
+		createErr := db.Table(table).Create(&lock).Error
+		if createErr == nil {
+			return refreshTableLock(db, table, name, owner), nil
+		}
+
+		holder := "another process"
+		var current seedLock
+		if err := db.Table(table).Where("name = ?", name).First(&current).Error; err == nil {
+			holder = fmt.Sprintf("%s since %s", current.Owner, current.AcquiredAt.Format(time.RFC3339))
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Either the holder just released it, or the insert failed for
+			// another reason
+			holder = fmt.Sprintf("another process (last error: %v)", createErr)
+		}
+
+		if err := waitForLock(ctx, deadline); err != nil {
+			return nil, lockNotAcquired(holder, timeout, err)
+		}
+	}
+}
+
+// refreshTableLock keeps a held lock row from expiring and returns the
+// function releasing it
+func refreshTableLock(db *gorm.DB, table, name, owner string) func() {
+	held := db.Table(table).Where("name = ? AND owner = ?", name, owner).Session(&gorm.Session{})
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(lockExpiry / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				held.Update("expires_at", time.Now().Add(lockExpiry))
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+		held.Delete(&seedLock{})
+	}
+}
+
+// errLockDeadline is returned by waitForLock once the wait timeout passed
+var errLockDeadline = errors.New("lock wait timeout")
+
+// waitForLock sleeps until the next attempt, failing once deadline passed or
+// ctx is done
+func waitForLock(ctx context.Context, deadline time.Time) error {
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return errLockDeadline
+	}
+
+	timer := time.NewTimer(min(lockPollInterval, remaining))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// lockNotAcquired describes why waiting for a held lock failed
+func lockNotAcquired(holder string, timeout time.Duration, err error) error {
+	if errors.Is(err, errLockDeadline) {
+		return fmt.Errorf("%w: held by %s after waiting %s", ErrLockTimeout, holder, timeout)
+	}
+	return fmt.Errorf("%w: held by %s: %w", ErrLockTimeout, holder, err)
+}
+
+// lockKey maps a lock name to a Postgres advisory lock key
+func lockKey(name string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	return int64(hash.Sum64())
+}
+
+// lockOwner identifies this process in the lock table
+func lockOwner() (string, error) {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate lock owner: %w", err)
+	}
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix)), nil
+}
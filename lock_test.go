@@ -0,0 +1,158 @@
+package gorm_seed
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupFileDB opens a second connection pool on a shared SQLite file, like
+// another process would
+func setupFileDB(t *testing.T, path string) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(path+"?_busy_timeout=5000"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	return db
+}
+
+func TestRunAllWithOptions_LockHeldByAnotherProcess(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	if err := db.Table(DefaultLockTable).AutoMigrate(&seedLock{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	db.Table(DefaultLockTable).Create(&seedLock{
+		Name:       DefaultLockName,
+		Owner:      "replica-2",
+		AcquiredAt: time.Now(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	})
+
+	executed := false
+	Register(&mockSeeder{name: "001_users", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		executed = true
+		return nil
+	}})
+
+	err := RunAllWithOptions(db, nil, RunOptions{Lock: true, LockTimeout: 200 * time.Millisecond})
+
+	var lockErr *LockError
+	if !errors.As(err, &lockErr) || lockErr.Name != DefaultLockName {
+		t.Fatalf("expected LockError, got %v", err)
+	}
+	// The lock failure is a SeederError of the run, not of a seeder
+	var seederErr *SeederError
+	if !errors.As(err, &seederErr) || seederErr.SeederName != "" || strings.Contains(err.Error(), "001_users") {
+		t.Errorf("expected a SeederError without a seeder name, got: %v", err)
+	}
+	if !errors.Is(err, ErrLockTimeout) || !strings.Contains(err.Error(), `lock "gorm_seed"`) || !strings.Contains(err.Error(), "replica-2") {
+		t.Errorf("expected lock timeout naming the holder, got: %v", err)
+	}
+	if executed {
+		t.Error("expected seeder not to run without the lock")
+	}
+}
+
+func TestRunAllWithOptions_LockTakesOverExpiredLock(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	if err := db.Table(DefaultLockTable).AutoMigrate(&seedLock{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	db.Table(DefaultLockTable).Create(&seedLock{
+		Name:       DefaultLockName,
+		Owner:      "crashed-replica",
+		AcquiredAt: time.Now().Add(-time.Hour),
+		ExpiresAt:  time.Now().Add(-time.Minute),
+	})
+
+	var locks int64
+	Register(&mockSeeder{name: "001_users", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		return db.Table(DefaultLockTable).Where("owner <> ?", "crashed-replica").Count(&locks).Error
+	}})
+
+	if err := RunAllWithOptions(db, nil, RunOptions{Lock: true, LockTimeout: time.Second}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if locks != 1 {
+		t.Errorf("expected the run to hold the lock while seeding, got %d lock rows", locks)
+	}
+
+	var remaining int64
+	db.Table(DefaultLockTable).Count(&remaining)
+	if remaining != 0 {
+		t.Errorf("expected the lock to be released, got %d lock rows", remaining)
+	}
+}
+
+func TestRunAllWithOptions_LockSerializesProcesses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seed.db")
+
+	var runs atomic.Int32
+	seeder := &mockSeeder{name: "001_users", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		runs.Add(1)
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	}}
+
+	// Each replica has its own registry and connection pool
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		reg := NewRegistry()
+		reg.Register(seeder)
+		db := setupFileDB(t, path)
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = reg.RunAllWithOptions(db, nil, RunOptions{
+				Lock:         true,
+				LockTimeout:  5 * time.Second,
+				SkipExecuted: true,
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("expected run %d to succeed, got: %v", i, err)
+		}
+	}
+	if runs.Load() != 1 {
+		t.Errorf("expected the seeder to run once across both runs, got %d", runs.Load())
+	}
+}
+
+func TestCheckLockPool(t *testing.T) {
+	db := setupTestDB(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get pool: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	// A sequential run seeds on the pinned connection
+	if err := checkLockPool(db, RunOptions{Lock: true}); err != nil {
+		t.Errorf("expected sequential run to be allowed, got: %v", err)
+	}
+	if err := checkLockPool(db, RunOptions{Lock: true, Concurrency: 4}); err == nil {
+		t.Error("expected parallel run on a single connection to fail")
+	}
+
+	sqlDB.SetMaxOpenConns(2)
+	if err := checkLockPool(db, RunOptions{Lock: true, Concurrency: 4}); err != nil {
+		t.Errorf("expected parallel run to be allowed, got: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package gorm_seed
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"gorm.io/gorm"
+)
+
+// PanicError is the error of a seeder that panicked. It is wrapped in the
+// seeder's SeederError, use errors.As to get the panic value and stack.
+type PanicError struct {
+	// Value is the value passed to panic
+	Value interface{}
+	// Stack is the stack trace of the panicking goroutine
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value when it is an error, such as a
+// runtime.Error
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// recoverPanic wraps fn so a panic is returned as a *PanicError. The error
+// is returned from inside the transaction, which is then rolled back like
+// for any other failure.
+func recoverPanic(fn func(tx *gorm.DB) error) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{
+					Value: r,
+					Stack: debug.Stack(),
+				}
+			}
+		}()
+		return fn(tx)
+	}
+}
@@ -0,0 +1,145 @@
+package gorm_seed
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func panicAfterInsert(name string) func(db *gorm.DB, deps map[string]interface{}) error {
+	return func(db *gorm.DB, deps map[string]interface{}) error {
+		if err := db.Create(&testRecord{Name: name}).Error; err != nil {
+			return err
+		}
+		var record *testRecord
+		_ = record.Name
+		return nil
+	}
+}
+
+func TestRunAllWithOptions_PanicContinueOnError(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{name: "001_panics", seedFunc: panicAfterInsert("panicked")})
+	Register(&mockSeeder{name: "002_records", seedFunc: insertRecord("kept")})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Transaction:     TransactionPerSeeder,
+		ContinueOnError: true,
+	})
+
+	var seederErrs *SeederErrors
+	if !errors.As(err, &seederErrs) || len(seederErrs.Errors) != 1 {
+		t.Fatalf("expected one seeder error, got %v", err)
+	}
+	if seederErrs.Errors[0].SeederName != "001_panics" {
+		t.Errorf("expected error of 001_panics, got %s", seederErrs.Errors[0].SeederName)
+	}
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected PanicError, got %v", err)
+	}
+	if !strings.Contains(string(panicErr.Stack), "panicAfterInsert") {
+		t.Errorf("expected stack to include the panicking function, got:\n%s", panicErr.Stack)
+	}
+	var runtimeErr runtime.Error
+	if !errors.As(err, &runtimeErr) {
+		t.Errorf("expected runtime.Error to be unwrapped, got %v", err)
+	}
+
+	// The panicking seeder's transaction was rolled back
+	if names := recordNames(t, db); len(names) != 1 || names[0] != "kept" {
+		t.Errorf("expected only the record of 002_records, got %v", names)
+	}
+}
+
+func TestRunAllWithOptions_PanicStopsRun(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	executed := false
+	Register(&mockSeeder{name: "001_panics", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		panic("boom")
+	}})
+	Register(&mockSeeder{name: "002_users", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		executed = true
+		return nil
+	}})
+
+	err := RunAllWithOptions(db, nil, RunOptions{})
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "boom" {
+		t.Fatalf("expected PanicError of boom, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "001_panics") || !strings.Contains(err.Error(), "panic: boom") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+	if executed {
+		t.Error("expected run to stop after the panic")
+	}
+}
+
+func TestRunAllWithOptions_PanicInTransactionAll(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{name: "001_records", seedFunc: insertRecord("first")})
+	Register(&mockSeeder{name: "002_panics", seedFunc: panicAfterInsert("panicked")})
+
+	err := RunAllWithOptions(db, nil, RunOptions{Transaction: TransactionAll})
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected PanicError, got %v", err)
+	}
+	if names := recordNames(t, db); len(names) != 0 {
+		t.Errorf("expected the whole run to be rolled back, got %v", names)
+	}
+}
+
+func TestRunSpecific_Panic(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	Register(&mockSeeder{name: "001_panics", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		panic("boom")
+	}})
+
+	err := RunSpecific("001_panics", db, nil)
+
+	var seederErr *SeederError
+	if !errors.As(err, &seederErr) || seederErr.SeederName != "001_panics" {
+		t.Fatalf("expected SeederError of 001_panics, got %v", err)
+	}
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || len(panicErr.Stack) == 0 {
+		t.Errorf("expected PanicError with stack, got %v", err)
+	}
+}
+
+func TestRunAllWithOptions_PanicNotRetried(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	attempts := 0
+	Register(&mockSeeder{name: "001_panics", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		attempts++
+		panic(errLocked)
+	}})
+
+	err := RunAllWithOptions(db, nil, RunOptions{Retry: RetryPolicy{MaxAttempts: 3}})
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected PanicError, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected panics not to be retried, got %d attempts", attempts)
+	}
+}
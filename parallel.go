@@ -0,0 +1,141 @@
+package gorm_seed
+
+import (
+	"context"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// seederResult is the outcome of a seeder run by a parallel worker
+type seederResult struct {
+	index int
+	err   *SeederError
+}
+
+// executeParallel runs up to opts.Concurrency seeders at the same time. A
+// seeder starts once every seeder it depends on has finished (when unseeding,
+// once every seeder depending on it has finished). Seeders become ready in
+// the order of seeders.
+func executeParallel(ctx context.Context, seeders []Seeder, db *gorm.DB, deps map[string]interface{}, opts RunOptions, history *seedHistory, dir direction) error {
+	index := make(map[string]int, len(seeders))
+	for i := len(seeders) - 1; i >= 0; i-- {
+		index[seeders[i].Name()] = i
+	}
+
+	// waiting[i] counts the seeders that must finish before seeders[i] starts
+	waiting := make([]int, len(seeders))
+	unblocks := make([][]int, len(seeders))
+	for i, seeder := range seeders {
+		for _, dep := range dependenciesOf(seeder) {
+			j, ok := index[dep]
+			if !ok {
+				// Dependencies outside the selection are not waited for
+				continue
+			}
+
+			before, after := j, i
+			if dir == directionUnseed {
+				before, after = i, j
+			}
+			waiting[after]++
+			unblocks[before] = append(unblocks[before], after)
+		}
+	}
+
+	ready := make([]int, 0, len(seeders))
+	for i := range seeders {
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	results := make(chan seederResult)
+	started := make([]bool, len(seeders))
+	errs := make([]*SeederError, len(seeders))
+	failed := make(map[string]bool)
+	running := 0
+	var firstErr *SeederError
+	var cancelled error
+
+	for {
+		for firstErr == nil && cancelled == nil && running < opts.Concurrency && len(ready) > 0 {
+			if err := ctx.Err(); err != nil {
+				cancelled = err
+				break
+			}
+
+			i := ready[0]
+			ready = ready[1:]
+
+			// blockedBy is only non-nil with ContinueOnError
+			blocked := blockedBy(seeders[i], seeders, failed, dir)
+			started[i] = true
+			running++
+			go func(i int, blocked error) {
+				err := executeSeeder(ctx, seeders[i], blocked, db, deps, opts, history, dir)
+				results <- seederResult{index: i, err: err}
+			}(i, blocked)
+		}
+
+		if running == 0 {
+			break
+		}
+
+		result := <-results
+		running--
+
+		if result.err != nil {
+			name := seeders[result.index].Name()
+			errs[result.index] = result.err
+			failed[name] = true
+
+			if !opts.ContinueOnError && firstErr == nil {
+				firstErr = result.err
+			}
+		}
+
+		for _, j := range unblocks[result.index] {
+			waiting[j]--
+			if waiting[j] == 0 {
+				pos := sort.SearchInts(ready, j)
+				ready = append(ready, 0)
+				copy(ready[pos+1:], ready[pos:])
+				ready[pos] = j
+			}
+		}
+	}
+
+	if firstErr != nil || cancelled != nil {
+		reason := ReasonEarlierFailure
+		if firstErr == nil {
+			reason = ReasonCancelled
+		}
+		var remaining []Seeder
+		for i, seeder := range seeders {
+			if !started[i] {
+				remaining = append(remaining, seeder)
+			}
+		}
+		skipRemaining(remaining, reason, opts, history, dir)
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if cancelled != nil {
+		return cancelled
+	}
+
+	errors := &SeederErrors{}
+	for _, err := range errs {
+		if err != nil {
+			errors.Errors = append(errors.Errors, err)
+		}
+	}
+	if errors.HasErrors() {
+		return errors
+	}
+
+	return nil
+}
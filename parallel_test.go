@@ -0,0 +1,216 @@
+package gorm_seed
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestRunAllWithOptions_ConcurrentIndependentSeeders(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	// Each seeder waits until the other one has started, which only
+	// succeeds when both run at the same time
+	started := map[string]chan struct{}{
+		"001_users":    make(chan struct{}),
+		"002_products": make(chan struct{}),
+	}
+	other := map[string]string{"001_users": "002_products", "002_products": "001_users"}
+
+	for name := range started {
+		name := name
+		Register(&mockSeeder{
+			name: name,
+			seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+				close(started[name])
+				select {
+				case <-started[other[name]]:
+					return nil
+				case <-time.After(time.Second):
+					return errors.New("seeders did not run concurrently")
+				}
+			},
+		})
+	}
+
+	if err := RunAllWithOptions(db, nil, RunOptions{Concurrency: 2}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
+
+func TestRunAllWithOptions_ConcurrentRespectsDependencies(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	var usersDone atomic.Bool
+	Register(&mockSeeder{
+		name: "001_users",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			time.Sleep(20 * time.Millisecond)
+			usersDone.Store(true)
+			return nil
+		},
+	})
+	Register(&mockDependentSeeder{
+		mockSeeder: mockSeeder{
+			name: "002_orders",
+			seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+				if !usersDone.Load() {
+					return errors.New("ran before its dependency finished")
+				}
+				return nil
+			},
+		},
+		deps: []string{"001_users"},
+	})
+	Register(&mockSeeder{name: "003_products"})
+
+	if err := RunAllWithOptions(db, nil, RunOptions{Concurrency: 4}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
+
+func TestRunAllWithOptions_ConcurrentErrorsAndCallbacks(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	Register(&mockSeeder{name: "001_ok"})
+	Register(&mockSeeder{
+		name: "002_failing",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			return errors.New("second failure")
+		},
+	})
+	Register(&mockSeeder{
+		name: "003_failing",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			return errors.New("third failure")
+		},
+	})
+	Register(&mockDependentSeeder{mockSeeder: mockSeeder{name: "004_blocked"}, deps: []string{"002_failing"}})
+	Register(&mockSeeder{name: "005_ok"})
+
+	// The callbacks deliberately use unguarded slices: the runner must not
+	// call them concurrently
+	started := []string{}
+	completed := []string{}
+	failed := []string{}
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		ContinueOnError: true,
+		Concurrency:     3,
+		OnSeederStart: func(name string) {
+			started = append(started, name)
+		},
+		OnSeederComplete: func(name string) {
+			completed = append(completed, name)
+		},
+		OnSeederError: func(name string, err error) {
+			failed = append(failed, name)
+		},
+	})
+
+	var seederErrs *SeederErrors
+	if !errors.As(err, &seederErrs) {
+		t.Fatalf("expected SeederErrors, got %T", err)
+	}
+
+	expected := []string{"002_failing", "003_failing", "004_blocked"}
+	if len(seederErrs.Errors) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %v", len(expected), len(seederErrs.Errors), seederErrs.Errors)
+	}
+	for i, name := range expected {
+		if seederErrs.Errors[i].SeederName != name {
+			t.Errorf("expected error %d to be from %s, got %s", i, name, seederErrs.Errors[i].SeederName)
+		}
+	}
+
+	if len(started) != 5 || len(completed) != 2 || len(failed) != 3 {
+		t.Errorf("unexpected callbacks: started %v, completed %v, failed %v", started, completed, failed)
+	}
+}
+
+func TestRunAllWithOptions_ConcurrentFailFast(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	var mu sync.Mutex
+	executed := []string{}
+	record := func(name string, err error) func(db *gorm.DB, deps map[string]interface{}) error {
+		return func(db *gorm.DB, deps map[string]interface{}) error {
+			mu.Lock()
+			executed = append(executed, name)
+			mu.Unlock()
+			return err
+		}
+	}
+
+	Register(&mockSeeder{name: "001_failing", seedFunc: record("001_failing", errors.New("intentional failure"))})
+	Register(&mockDependentSeeder{mockSeeder: mockSeeder{name: "002_after", seedFunc: record("002_after", nil)}, deps: []string{"001_failing"}})
+
+	err := RunAllWithOptions(db, nil, RunOptions{Concurrency: 2})
+
+	var seederErr *SeederError
+	if !errors.As(err, &seederErr) || seederErr.SeederName != "001_failing" {
+		t.Fatalf("expected SeederError from 001_failing, got %v", err)
+	}
+
+	if len(executed) != 1 {
+		t.Errorf("expected no seeder to start after the failure, got %v", executed)
+	}
+}
+
+func TestRollbackAll_Concurrent(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	var mu sync.Mutex
+	unseeded := []string{}
+	register := func(name string, deps ...string) {
+		Register(&mockReversibleSeeder{
+			mockSeeder: mockSeeder{name: name},
+			deps:       deps,
+			unseedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+				mu.Lock()
+				unseeded = append(unseeded, name)
+				mu.Unlock()
+				return nil
+			},
+		})
+	}
+
+	register("001_users")
+	register("002_orders", "001_users")
+	register("003_order_items", "002_orders")
+
+	if err := RollbackAll(db, nil, RunOptions{Concurrency: 3}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := []string{"003_order_items", "002_orders", "001_users"}
+	for i, name := range expected {
+		if i >= len(unseeded) || unseeded[i] != name {
+			t.Fatalf("expected rollback order %v, got %v", expected, unseeded)
+		}
+	}
+}
+
+func TestRunAllWithOptions_ConcurrencyWithTransactionAll(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	Register(&mockSeeder{name: "001_users"})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Concurrency: 2,
+		Transaction: TransactionAll,
+	})
+	if err == nil {
+		t.Error("expected error when combining concurrency with TransactionAll, got nil")
+	}
+}
@@ -0,0 +1,71 @@
+package gorm_seed
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/lunar-kiln/gorm-seed/fake"
+	"gorm.io/gorm"
+)
+
+// fakerKey is the context key of the faker of a seeder
+type fakerKey struct{}
+
+// withFaker returns a context carrying the faker of a seeder
+func withFaker(ctx context.Context, faker *fake.Faker) context.Context {
+	return context.WithValue(ctx, fakerKey{}, faker)
+}
+
+// FakerFromContext returns the faker of the running seeder, see
+// RunOptions.RandomSeed. Outside a run it returns a faker with a random seed.
+func FakerFromContext(ctx context.Context) *fake.Faker {
+	if ctx != nil {
+		if faker, ok := ctx.Value(fakerKey{}).(*fake.Faker); ok {
+			return faker
+		}
+	}
+
+	seed, err := newRandomSeed()
+	if err != nil {
+		seed = 1
+	}
+	return fake.New(seed)
+}
+
+// Faker returns the faker of the seeder running on db, the handle passed to
+// Seed, see FakerFromContext
+func Faker(db *gorm.DB) *fake.Faker {
+	if db == nil || db.Statement == nil {
+		return FakerFromContext(context.Background())
+	}
+	return FakerFromContext(db.Statement.Context)
+}
+
+// seederRandomSeed derives the seed of a seeder's faker from the seed of the
+// run and the seeder's name, so a seeder's data does not depend on which
+// other seeders run before it
+func seederRandomSeed(runSeed int64, name string) int64 {
+	h := fnv.New64a()
+	var seed [8]byte
+	binary.BigEndian.PutUint64(seed[:], uint64(runSeed))
+	h.Write(seed[:])
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// newRandomSeed returns a random non-zero seed
+func newRandomSeed() (int64, error) {
+	var b [8]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return 0, fmt.Errorf("failed to generate random seed: %w", err)
+		}
+		// Keep seeds positive so they can be passed back as a flag
+		if seed := int64(binary.BigEndian.Uint64(b[:]) >> 1); seed != 0 {
+			return seed, nil
+		}
+	}
+}
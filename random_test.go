@@ -0,0 +1,103 @@
+package gorm_seed
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// registerFakeSeeders registers seeders that record a fake name per run
+func registerFakeSeeders(names map[string]string) {
+	record := func(name string) func(db *gorm.DB, deps map[string]interface{}) error {
+		return func(db *gorm.DB, deps map[string]interface{}) error {
+			names[name] = Faker(db).Name() + " " + Faker(db).Email()
+			return nil
+		}
+	}
+	Register(&mockSeeder{name: "001_users", seedFunc: record("001_users")})
+	Register(&mockSeeder{name: "002_customers", seedFunc: record("002_customers")})
+}
+
+func TestRunAllWithOptions_RandomSeedReproducesData(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	first := make(map[string]string)
+	registerFakeSeeders(first)
+	if err := RunAllWithOptions(db, nil, RunOptions{RandomSeed: 42}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// The same seed reproduces each seeder's data, also when other seeders do not run
+	Clear()
+	second := make(map[string]string)
+	registerFakeSeeders(second)
+	if err := RunAllWithOptions(db, nil, RunOptions{RandomSeed: 42, Only: []string{"002_*"}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if second["002_customers"] != first["002_customers"] {
+		t.Errorf("expected %q again, got %q", first["002_customers"], second["002_customers"])
+	}
+	if first["001_users"] == first["002_customers"] {
+		t.Errorf("expected seeders to get different data, both got %q", first["001_users"])
+	}
+}
+
+func TestRunAllWithOptions_RandomSeedReported(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	names := make(map[string]string)
+	registerFakeSeeders(names)
+
+	report := NewReport()
+	if err := RunAllWithOptions(db, nil, RunOptions{Observers: []Observer{report}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if report.RandomSeed <= 0 {
+		t.Fatalf("expected a random seed to be picked, got %d", report.RandomSeed)
+	}
+
+	// Passing the reported seed back reproduces the run
+	Clear()
+	again := make(map[string]string)
+	registerFakeSeeders(again)
+	if err := RunAllWithOptions(db, nil, RunOptions{RandomSeed: report.RandomSeed}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	for name, value := range names {
+		if again[name] != value {
+			t.Errorf("expected %s to reproduce %q, got %q", name, value, again[name])
+		}
+	}
+}
+
+func TestRunAllWithOptions_RandomSeedSameOnRetry(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	var attempts []string
+	Register(&mockSeeder{name: "001_users", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		attempts = append(attempts, Faker(db).Name())
+		if len(attempts) < 2 {
+			return errLocked
+		}
+		return nil
+	}})
+
+	err := RunAllWithOptions(db, nil, RunOptions{RandomSeed: 7, Retry: RetryPolicy{MaxAttempts: 2}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(attempts) != 2 || attempts[0] != attempts[1] {
+		t.Errorf("expected both attempts to get the same data, got %v", attempts)
+	}
+}
+
+func TestFaker_OutsideRun(t *testing.T) {
+	if Faker(setupTestDB(t)) == nil || FakerFromContext(context.Background()) == nil {
+		t.Error("expected a faker outside a run")
+	}
+}
@@ -0,0 +1,278 @@
+package gorm_seed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// StatusSkipped is reported for seeders that did not run
+const StatusSkipped = "skipped"
+
+// SeederReport is the outcome of a single seeder in a run
+type SeederReport struct {
+	Name string
+	// Status is StatusSuccess, StatusRolledBack, StatusFailed or StatusSkipped
+	Status   string
+	Duration time.Duration
+	// Attempts is how often the seeder ran, see RetryPolicy
+	Attempts     int
+	Error        string
+	SkipReason   string
+	RowsAffected map[string]int64
+}
+
+// Report collects the outcome of every seeder of a run. It is an Observer:
+// add it to RunOptions.Observers and write it once the run returned. The
+// zero value is an empty report ready to use.
+type Report struct {
+	mu sync.Mutex
+
+	RunID      string
+	RandomSeed int64
+	StartedAt  time.Time
+	Duration   time.Duration
+	// RolledBack is true when the run's transaction was rolled back, see
+	// TransactionAll. Seeders that had finished are then StatusRolledBack.
+	RolledBack bool
+	Seeders    []SeederReport
+
+	index map[string]int
+}
+
+// NewReport creates an empty run report
+func NewReport() *Report {
+	return &Report{
+		index: make(map[string]int),
+	}
+}
+
+// OnEvent records the event in the report
+func (r *Report) OnEvent(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.index == nil {
+		r.index = make(map[string]int)
+	}
+	if r.StartedAt.IsZero() {
+		r.RunID = event.RunID
+		r.RandomSeed = event.RandomSeed
+		r.StartedAt = event.Time
+	}
+	r.Duration = event.Time.Sub(r.StartedAt)
+
+	if event.Type == EventRolledBack {
+		r.RolledBack = true
+		for i := range r.Seeders {
+			if r.Seeders[i].Status == StatusSuccess {
+				r.Seeders[i].Status = StatusRolledBack
+			}
+		}
+		return
+	}
+
+	i, ok := r.index[event.SeederName]
+	if !ok {
+		i = len(r.Seeders)
+		r.index[event.SeederName] = i
+		r.Seeders = append(r.Seeders, SeederReport{Name: event.SeederName})
+	}
+	seeder := &r.Seeders[i]
+
+	switch event.Type {
+	case EventSkip:
+		seeder.Status = StatusSkipped
+		seeder.SkipReason = event.Reason
+	case EventFinish:
+		seeder.Status = StatusSuccess
+		if event.Rollback {
+			seeder.Status = StatusRolledBack
+		}
+		seeder.Duration = event.Duration
+		seeder.Attempts = event.Attempt
+		seeder.RowsAffected = event.RowsAffected
+	case EventError:
+		seeder.Status = StatusFailed
+		seeder.Duration = event.Duration
+		seeder.Attempts = event.Attempt
+		seeder.RowsAffected = event.RowsAffected
+		seeder.Error = event.Err.Error()
+	}
+}
+
+// counts returns the number of failed and skipped seeders
+func (r *Report) counts() (failed, skipped int) {
+	for _, seeder := range r.Seeders {
+		switch seeder.Status {
+		case StatusFailed:
+			failed++
+		case StatusSkipped:
+			skipped++
+		}
+	}
+	return failed, skipped
+}
+
+// Write writes the report in format, "json" or "junit"
+func (r *Report) Write(format string, w io.Writer) error {
+	switch format {
+	case "json":
+		return r.WriteJSON(w)
+	case "junit":
+		return r.WriteJUnit(w)
+	default:
+		return fmt.Errorf("unknown report format %q, expected json or junit", format)
+	}
+}
+
+// jsonReport is the JSON encoding of a Report
+type jsonReport struct {
+	RunID      string             `json:"run_id"`
+	RandomSeed int64              `json:"random_seed"`
+	StartedAt  time.Time          `json:"started_at"`
+	Duration   float64            `json:"duration_seconds"`
+	RolledBack bool               `json:"rolled_back"`
+	Total      int                `json:"total"`
+	Failed     int                `json:"failed"`
+	Skipped    int                `json:"skipped"`
+	Seeders    []jsonSeederReport `json:"seeders"`
+}
+
+// jsonSeederReport is the JSON encoding of a SeederReport
+type jsonSeederReport struct {
+	Name         string           `json:"name"`
+	Status       string           `json:"status"`
+	Duration     float64          `json:"duration_seconds"`
+	Attempts     int              `json:"attempts,omitempty"`
+	Error        string           `json:"error,omitempty"`
+	SkipReason   string           `json:"skip_reason,omitempty"`
+	RowsAffected map[string]int64 `json:"rows_affected,omitempty"`
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	failed, skipped := r.counts()
+	report := jsonReport{
+		RunID:      r.RunID,
+		RandomSeed: r.RandomSeed,
+		StartedAt:  r.StartedAt,
+		Duration:   r.Duration.Seconds(),
+		RolledBack: r.RolledBack,
+		Total:      len(r.Seeders),
+		Failed:     failed,
+		Skipped:    skipped,
+		Seeders:    make([]jsonSeederReport, len(r.Seeders)),
+	}
+	for i, seeder := range r.Seeders {
+		report.Seeders[i] = jsonSeederReport{
+			Name:         seeder.Name,
+			Status:       seeder.Status,
+			Duration:     seeder.Duration.Seconds(),
+			Attempts:     seeder.Attempts,
+			Error:        seeder.Error,
+			SkipReason:   seeder.SkipReason,
+			RowsAffected: seeder.RowsAffected,
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite holds the seeders of a run
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	ID        string          `xml:"id,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is a single seeder
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+// junitMessage is a failure or skip reason
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML, with one test case per seeder
+func (r *Report) WriteJUnit(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	failed, skipped := r.counts()
+	if r.RolledBack {
+		// Seeders whose changes were rolled back are reported as skipped
+		skipped = len(r.Seeders) - failed
+	}
+	suite := junitTestSuite{
+		Name:     "gorm-seed",
+		Tests:    len(r.Seeders),
+		Failures: failed,
+		Skipped:  skipped,
+		Time:     junitSeconds(r.Duration),
+		ID:       r.RunID,
+		Cases:    make([]junitTestCase, len(r.Seeders)),
+	}
+	if !r.StartedAt.IsZero() {
+		suite.Timestamp = r.StartedAt.UTC().Format("2006-01-02T15:04:05")
+	}
+
+	for i, seeder := range r.Seeders {
+		testCase := junitTestCase{
+			Name:      seeder.Name,
+			ClassName: "seeders",
+			Time:      junitSeconds(seeder.Duration),
+		}
+		switch {
+		case seeder.Status == StatusFailed:
+			testCase.Failure = &junitMessage{Message: seeder.Error, Text: seeder.Error}
+		case seeder.Status == StatusSkipped:
+			testCase.Skipped = &junitMessage{Message: seeder.SkipReason}
+		case r.RolledBack:
+			testCase.Skipped = &junitMessage{Message: "changes rolled back after a failure"}
+		}
+		suite.Cases[i] = testCase
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitSeconds formats a duration in seconds, as JUnit expects
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,270 @@
+package gorm_seed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// runReportedSeeders runs a successful, a failing and an already executed
+// seeder and returns the report of the second run
+func runReportedSeeders(t *testing.T) *Report {
+	t.Helper()
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{name: "001_users", seedFunc: insertRecord("alice")})
+	Register(&mockSeeder{
+		name: "002_failing",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			return errors.New("intentional <failure>")
+		},
+	})
+	Register(&mockVersionedSeeder{
+		mockSeeder: mockSeeder{name: "003_countries", seedFunc: insertRecord("nl")},
+		version:    "v1",
+	})
+
+	opts := RunOptions{ContinueOnError: true, SkipExecuted: true}
+	RunAllWithOptions(db, nil, opts)
+
+	report := NewReport()
+	opts.RunID = "run-1"
+	opts.Observers = []Observer{report}
+	if err := RunAllWithOptions(db, nil, opts); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	return report
+}
+
+func TestReport(t *testing.T) {
+	report := runReportedSeeders(t)
+
+	if report.RunID != "run-1" || report.StartedAt.IsZero() {
+		t.Errorf("unexpected run details: %s, %v", report.RunID, report.StartedAt)
+	}
+
+	expected := []struct{ name, status string }{
+		{"001_users", StatusSkipped},
+		{"002_failing", StatusFailed},
+		{"003_countries", StatusSkipped},
+	}
+	if len(report.Seeders) != len(expected) {
+		t.Fatalf("expected %d seeders, got %+v", len(expected), report.Seeders)
+	}
+	for i, e := range expected {
+		if report.Seeders[i].Name != e.name || report.Seeders[i].Status != e.status {
+			t.Errorf("expected %s to be %s, got %+v", e.name, e.status, report.Seeders[i])
+		}
+	}
+	if report.Seeders[0].SkipReason != ReasonExecuted {
+		t.Errorf("expected skip reason, got '%s'", report.Seeders[0].SkipReason)
+	}
+	if report.Seeders[1].Error != "intentional <failure>" {
+		t.Errorf("expected error text, got '%s'", report.Seeders[1].Error)
+	}
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	report := runReportedSeeders(t)
+
+	var buf bytes.Buffer
+	if err := report.Write("json", &buf); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	var decoded struct {
+		RunID   string `json:"run_id"`
+		Total   int    `json:"total"`
+		Failed  int    `json:"failed"`
+		Skipped int    `json:"skipped"`
+		Seeders []struct {
+			Name       string `json:"name"`
+			Status     string `json:"status"`
+			Error      string `json:"error"`
+			SkipReason string `json:"skip_reason"`
+		} `json:"seeders"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("expected valid JSON, got: %v\n%s", err, buf.String())
+	}
+	if decoded.RunID != "run-1" || decoded.Total != 3 || decoded.Failed != 1 || decoded.Skipped != 2 {
+		t.Errorf("unexpected totals: %+v", decoded)
+	}
+	if decoded.Seeders[1].Error != "intentional <failure>" || decoded.Seeders[0].SkipReason != ReasonExecuted {
+		t.Errorf("unexpected seeders: %+v", decoded.Seeders)
+	}
+}
+
+func TestReport_WriteJUnit(t *testing.T) {
+	report := runReportedSeeders(t)
+
+	var buf bytes.Buffer
+	if err := report.Write("junit", &buf); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "<?xml") {
+		t.Errorf("expected XML header, got %s", buf.String())
+	}
+
+	var decoded struct {
+		Suites []struct {
+			Tests    int `xml:"tests,attr"`
+			Failures int `xml:"failures,attr"`
+			Skipped  int `xml:"skipped,attr"`
+			Cases    []struct {
+				Name    string `xml:"name,attr"`
+				Failure *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"failure"`
+				Skipped *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"skipped"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("expected valid XML, got: %v\n%s", err, buf.String())
+	}
+
+	if len(decoded.Suites) != 1 {
+		t.Fatalf("expected 1 test suite, got %d", len(decoded.Suites))
+	}
+	suite := decoded.Suites[0]
+	if suite.Tests != 3 || suite.Failures != 1 || suite.Skipped != 2 {
+		t.Errorf("unexpected totals: %+v", suite)
+	}
+	if suite.Cases[1].Failure == nil || suite.Cases[1].Failure.Message != "intentional <failure>" {
+		t.Errorf("expected failure for 002_failing, got %+v", suite.Cases[1])
+	}
+	if suite.Cases[0].Skipped == nil || suite.Cases[0].Skipped.Message != ReasonExecuted {
+		t.Errorf("expected skip for 001_users, got %+v", suite.Cases[0])
+	}
+}
+
+func TestReport_WriteUnknownFormat(t *testing.T) {
+	if err := NewReport().Write("yaml", &bytes.Buffer{}); err == nil {
+		t.Error("expected error for unknown format, got nil")
+	}
+}
+
+func TestReport_Rollback(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	unseeded := []string{}
+	registerReversible("001_users", &unseeded)
+
+	var report Report
+	if err := RollbackAll(db, nil, RunOptions{Observers: []Observer{&report}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(report.Seeders) != 1 || report.Seeders[0].Status != StatusRolledBack {
+		t.Errorf("expected rolled back seeder, got %+v", report.Seeders)
+	}
+}
+
+func TestReport_SeedersNotRun(t *testing.T) {
+	failing := func(db *gorm.DB, deps map[string]interface{}) error {
+		return errors.New("intentional failure")
+	}
+
+	for _, concurrency := range []int{1, 2} {
+		Clear()
+		db := setupTestDB(t)
+		Register(&mockSeeder{name: "001_a", seedFunc: failing})
+		Register(&mockSeeder{name: "002_b", seedFunc: failing})
+		Register(&mockSeeder{name: "003_c", seedFunc: failing})
+		Register(&mockDependentSeeder{mockSeeder: mockSeeder{name: "004_d", seedFunc: failing}, deps: []string{"001_a"}})
+
+		report := NewReport()
+		opts := RunOptions{Concurrency: concurrency, Observers: []Observer{report}}
+		if err := RunAllWithOptions(db, nil, opts); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		// Every selected seeder is reported, the ones that never started as skipped
+		if len(report.Seeders) != 4 {
+			t.Fatalf("concurrency %d: expected 4 seeders, got %+v", concurrency, report.Seeders)
+		}
+		for _, seeder := range report.Seeders {
+			if seeder.Status == StatusSkipped && seeder.SkipReason != ReasonEarlierFailure {
+				t.Errorf("concurrency %d: expected %s to be skipped after the failure, got %q", concurrency, seeder.Name, seeder.SkipReason)
+			}
+		}
+
+		var buf bytes.Buffer
+		if err := report.WriteJUnit(&buf); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(buf.String(), `tests="4"`) {
+			t.Errorf("concurrency %d: expected 4 tests, got:\n%s", concurrency, buf.String())
+		}
+	}
+}
+
+func TestReport_SeedersCancelled(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Register(&mockSeeder{name: "001_a", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		cancel()
+		return nil
+	}})
+	Register(&mockSeeder{name: "002_b"})
+
+	report := NewReport()
+	err := RunAllContext(ctx, db, nil, RunOptions{Observers: []Observer{report}})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got: %v", err)
+	}
+	if len(report.Seeders) != 2 || report.Seeders[1].SkipReason != ReasonCancelled {
+		t.Errorf("expected 002_b to be reported as cancelled, got %+v", report.Seeders)
+	}
+}
+
+func TestReport_TransactionAllRolledBack(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{name: "001_ok", seedFunc: insertRecord("ok")})
+	Register(&mockSeeder{name: "002_bad", seedFunc: insertThenFail("bad")})
+
+	report := NewReport()
+	err := RunAllWithOptions(db, nil, RunOptions{Transaction: TransactionAll, Observers: []Observer{report}})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if !report.RolledBack {
+		t.Error("expected the report to be rolled back")
+	}
+	if len(report.Seeders) != 2 || report.Seeders[0].Status != StatusRolledBack || report.Seeders[1].Status != StatusFailed {
+		t.Fatalf("expected 001_ok rolled back and 002_bad failed, got %+v", report.Seeders)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"rolled_back": true`) {
+		t.Errorf("expected rolled_back in JSON, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := report.WriteJUnit(&buf); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	junit := buf.String()
+	if !strings.Contains(junit, `failures="1" skipped="1"`) || !strings.Contains(junit, "changes rolled back") {
+		t.Errorf("expected the rolled back seeder to be skipped in JUnit, got:\n%s", junit)
+	}
+}
@@ -0,0 +1,106 @@
+package gorm_seed
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// RetryPolicy runs a failing seeder again when its error is transient, like
+// a deadlock or a locked SQLite database. Retries are only safe for seeders
+// whose failed attempts leave nothing behind, e.g. when they run inside a
+// transaction or are idempotent.
+type RetryPolicy struct {
+	// MaxAttempts is how often a seeder runs before its error is returned.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// Backoff returns the delay before the given attempt, starting at 2 for
+	// the first retry (optional, no delay when nil), see ExponentialBackoff
+	Backoff func(attempt int) time.Duration
+	// Retryable decides whether an error is worth another attempt
+	// (optional, default: IsTransientError)
+	Retryable func(err error) bool
+}
+
+// retryable reports whether the policy allows another attempt after err
+func (p RetryPolicy) retryable(attempt int, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsTransientError(err)
+}
+
+// wait sleeps before the given attempt, returning early with ctx's error
+func (p RetryPolicy) wait(ctx context.Context, attempt int) error {
+	if p.Backoff == nil {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(p.Backoff(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ExponentialBackoff returns a Backoff that waits base before the first
+// retry and doubles the delay for every further retry, up to max
+func ExponentialBackoff(base, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		delay := base
+		for i := 2; i < attempt && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			return max
+		}
+		return delay
+	}
+}
+
+// transientErrors are fragments of driver error messages for failures that
+// may succeed when tried again
+var transientErrors = []string{
+	// SQLite
+	"database is locked",
+	"database table is locked",
+	// Postgres deadlock_detected, serialization_failure
+	"deadlock detected",
+	"could not serialize access",
+	"SQLSTATE 40P01",
+	"SQLSTATE 40001",
+	// MySQL ER_LOCK_DEADLOCK, ER_LOCK_WAIT_TIMEOUT
+	"Error 1213",
+	"Error 1205",
+	"Deadlock found",
+	"Lock wait timeout exceeded",
+}
+
+// IsTransientError reports whether err looks like a deadlock, serialization
+// failure or lock timeout of SQLite, Postgres or MySQL. Context errors are
+// never transient, and neither are panics.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		return false
+	}
+
+	msg := err.Error()
+	for _, fragment := range transientErrors {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+package gorm_seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var errLocked = errors.New("database is locked")
+
+func TestRunAllWithOptions_RetryTransientError(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	attempts := 0
+	Register(&mockSeeder{name: "001_records", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		attempts++
+		if err := insertRecord(fmt.Sprintf("attempt-%d", attempts))(db, deps); err != nil {
+			return err
+		}
+		if attempts < 3 {
+			return errLocked
+		}
+		return nil
+	}})
+
+	type retry struct {
+		name    string
+		attempt int
+		err     error
+	}
+	var retries []retry
+	var events []Event
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Transaction: TransactionPerSeeder,
+		Retry:       RetryPolicy{MaxAttempts: 3},
+		Observers:   []Observer{recordEvents(&events)},
+		OnSeederRetry: func(name string, attempt int, err error) {
+			retries = append(retries, retry{name, attempt, err})
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if len(retries) != 2 || retries[0].attempt != 1 || retries[1].attempt != 2 {
+		t.Fatalf("expected retries after attempts 1 and 2, got %v", retries)
+	}
+	if retries[0].name != "001_records" || !errors.Is(retries[0].err, errLocked) {
+		t.Errorf("unexpected retry %v", retries[0])
+	}
+
+	// Failed attempts were rolled back
+	if names := recordNames(t, db); len(names) != 1 || names[0] != "attempt-3" {
+		t.Errorf("expected only the last attempt's record, got %v", names)
+	}
+
+	last := events[len(events)-1]
+	if last.Type != EventFinish || last.Attempt != 3 {
+		t.Errorf("expected finish event of attempt 3, got %s of attempt %d", last.Type, last.Attempt)
+	}
+}
+
+func TestRunAllWithOptions_RetryExhausted(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	attempts := 0
+	Register(&mockSeeder{name: "001_users", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		attempts++
+		return errLocked
+	}})
+
+	report := NewReport()
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Retry:     RetryPolicy{MaxAttempts: 3},
+		Observers: []Observer{report},
+	})
+
+	var seederErr *SeederError
+	if !errors.As(err, &seederErr) {
+		t.Fatalf("expected SeederError, got %v", err)
+	}
+	if attempts != 3 || seederErr.Attempts != 3 {
+		t.Errorf("expected 3 attempts, ran %d, recorded %d", attempts, seederErr.Attempts)
+	}
+	if !strings.Contains(err.Error(), "after 3 attempts") || !errors.Is(err, errLocked) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if report.Seeders[0].Attempts != 3 {
+		t.Errorf("expected report to record 3 attempts, got %d", report.Seeders[0].Attempts)
+	}
+}
+
+func TestRunAllWithOptions_RetrySkipsPermanentError(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	attempts := 0
+	Register(&mockSeeder{name: "001_users", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		attempts++
+		return errors.New("UNIQUE constraint failed: users.email")
+	}})
+
+	err := RunAllWithOptions(db, nil, RunOptions{Retry: RetryPolicy{MaxAttempts: 3}})
+
+	var seederErr *SeederError
+	if !errors.As(err, &seederErr) || seederErr.Attempts != 1 {
+		t.Fatalf("expected SeederError of a single attempt, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected no retries, got %d attempts", attempts)
+	}
+	if strings.Contains(err.Error(), "attempts") {
+		t.Errorf("expected no attempt count in message, got: %v", err)
+	}
+}
+
+func TestRunAllWithOptions_RetryClassifierAndBackoff(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	errFlaky := errors.New("flaky")
+	attempts := 0
+	Register(&mockSeeder{name: "001_users", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		attempts++
+		if attempts == 1 {
+			return errFlaky
+		}
+		return nil
+	}})
+
+	var delays []int
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Retry: RetryPolicy{
+			MaxAttempts: 2,
+			Backoff: func(attempt int) time.Duration {
+				delays = append(delays, attempt)
+				return time.Millisecond
+			},
+			Retryable: func(err error) bool {
+				return errors.Is(err, errFlaky)
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+	if len(delays) != 1 || delays[0] != 2 {
+		t.Errorf("expected a single backoff before attempt 2, got %v", delays)
+	}
+}
+
+func TestRunAllContext_RetryBackoffCancelled(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	Register(&mockSeeder{name: "001_users", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		attempts++
+		cancel()
+		return errLocked
+	}})
+
+	err := RunAllContext(ctx, db, nil, RunOptions{
+		Retry: RetryPolicy{MaxAttempts: 3, Backoff: ExponentialBackoff(time.Hour, time.Hour)},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got: %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected no retry after cancellation, got %d attempts", attempts)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+
+	expected := map[int]time.Duration{
+		2: 100 * time.Millisecond,
+		3: 200 * time.Millisecond,
+		4: 400 * time.Millisecond,
+		5: 800 * time.Millisecond,
+		6: time.Second,
+		9: time.Second,
+	}
+	for attempt, want := range expected {
+		if got := backoff(attempt); got != want {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, want, got)
+		}
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		err       error
+		transient bool
+	}{
+		{nil, false},
+		{errLocked, true},
+		{fmt.Errorf("insert: %w", errors.New("ERROR: deadlock detected (SQLSTATE 40P01)")), true},
+		{errors.New("Error 1213 (40001): Deadlock found when trying to get lock"), true},
+		{errors.New("UNIQUE constraint failed: users.email"), false},
+		{context.Canceled, false},
+		{fmt.Errorf("database is locked: %w", context.DeadlineExceeded), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsTransientError(tt.err); got != tt.transient {
+			t.Errorf("IsTransientError(%v) = %v, expected %v", tt.err, got, tt.transient)
+		}
+	}
+}
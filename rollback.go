@@ -0,0 +1,49 @@
+package gorm_seed
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ReversibleSeeder is implemented by seeders whose data can be removed again
+type ReversibleSeeder interface {
+	Seeder
+	// Unseed removes the data inserted by Seed
+	Unseed(db *gorm.DB, deps map[string]interface{}) error
+}
+
+// isReversible reports whether the seeder implements ReversibleSeeder
+func isReversible(seeder Seeder) bool {
+	_, ok := seeder.(ReversibleSeeder)
+	return ok
+}
+
+// unseed calls Unseed on a reversible seeder
+func unseed(seeder Seeder, db *gorm.DB, deps map[string]interface{}) error {
+	reversible, ok := seeder.(ReversibleSeeder)
+	if !ok {
+		return fmt.Errorf("seeder %s is not reversible", seeder.Name())
+	}
+	return reversible.Unseed(db, deps)
+}
+
+// RollbackAll unseeds all registered seeders selected by the range, pattern
+// and tag filters in opts, in reverse execution order. Seeders that are not
+// reversible are skipped. With history enabled in opts, only seeders whose
+// latest run succeeded are rolled back, and each rollback is recorded so the
+// seeder runs again on the next RunAll.
+func RollbackAll(db *gorm.DB, deps map[string]interface{}, opts RunOptions) error {
+	return registry.RollbackAll(db, deps, opts)
+}
+
+// RollbackLast unseeds the last n seeders of the execution order, in reverse.
+// With history enabled in opts, only seeders whose latest run succeeded count.
+func RollbackLast(n int, db *gorm.DB, deps map[string]interface{}, opts RunOptions) error {
+	return registry.RollbackLast(n, db, deps, opts)
+}
+
+// RollbackSpecific unseeds a specific seeder by name
+func RollbackSpecific(name string, db *gorm.DB, deps map[string]interface{}, opts RunOptions) error {
+	return registry.RollbackSpecific(name, db, deps, opts)
+}
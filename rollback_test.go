@@ -0,0 +1,201 @@
+package gorm_seed
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// mockReversibleSeeder is a test seeder that implements ReversibleSeeder
+type mockReversibleSeeder struct {
+	mockSeeder
+	deps       []string
+	unseedFunc func(db *gorm.DB, deps map[string]interface{}) error
+}
+
+func (m *mockReversibleSeeder) DependsOn() []string {
+	return m.deps
+}
+
+func (m *mockReversibleSeeder) Unseed(db *gorm.DB, deps map[string]interface{}) error {
+	if m.unseedFunc != nil {
+		return m.unseedFunc(db, deps)
+	}
+	return nil
+}
+
+// registerReversible registers a reversible seeder that appends its name to unseeded
+func registerReversible(name string, unseeded *[]string, deps ...string) {
+	Register(&mockReversibleSeeder{
+		mockSeeder: mockSeeder{name: name},
+		deps:       deps,
+		unseedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			*unseeded = append(*unseeded, name)
+			return nil
+		},
+	})
+}
+
+func TestRollbackAll(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	unseeded := []string{}
+	registerReversible("001_users", &unseeded)
+	registerReversible("002_orders", &unseeded, "003_products")
+	registerReversible("003_products", &unseeded)
+	Register(&mockSeeder{name: "004_settings"})
+
+	skipped := []string{}
+	err := RollbackAll(db, nil, RunOptions{
+		OnSeederSkip: func(name string) {
+			skipped = append(skipped, name)
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// Execution order is 001_users, 003_products, 002_orders, 004_settings
+	expected := []string{"002_orders", "003_products", "001_users"}
+	if strings.Join(unseeded, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected rollback order %v, got %v", expected, unseeded)
+	}
+
+	if len(skipped) != 1 || skipped[0] != "004_settings" {
+		t.Errorf("expected non-reversible seeder to be skipped, got %v", skipped)
+	}
+}
+
+func TestRollbackLast(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	unseeded := []string{}
+	registerReversible("001_users", &unseeded)
+	registerReversible("002_orders", &unseeded)
+	registerReversible("003_products", &unseeded)
+
+	if err := RollbackLast(2, db, nil, RunOptions{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := []string{"003_products", "002_orders"}
+	if strings.Join(unseeded, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected rollback of %v, got %v", expected, unseeded)
+	}
+
+	if err := RollbackLast(0, db, nil, RunOptions{}); err == nil {
+		t.Error("expected error for non-positive count, got nil")
+	}
+}
+
+func TestRollbackSpecific(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	unseeded := []string{}
+	registerReversible("001_users", &unseeded)
+	registerReversible("002_orders", &unseeded)
+	Register(&mockSeeder{name: "003_settings"})
+
+	if err := RollbackSpecific("001_users", db, nil, RunOptions{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(unseeded) != 1 || unseeded[0] != "001_users" {
+		t.Errorf("expected only 001_users to be rolled back, got %v", unseeded)
+	}
+
+	if err := RollbackSpecific("003_settings", db, nil, RunOptions{}); err == nil {
+		t.Error("expected error for non-reversible seeder, got nil")
+	}
+
+	if err := RollbackSpecific("non_existent", db, nil, RunOptions{}); err == nil {
+		t.Error("expected error for non-existent seeder, got nil")
+	}
+}
+
+func TestRollback_WithHistory(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	seeded := map[string]int{}
+	unseeded := []string{}
+	for _, name := range []string{"001_users", "002_orders", "003_products"} {
+		name := name
+		Register(&mockReversibleSeeder{
+			mockSeeder: mockSeeder{
+				name: name,
+				seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+					seeded[name]++
+					if name == "003_products" {
+						return errors.New("intentional failure")
+					}
+					return nil
+				},
+			},
+			unseedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+				unseeded = append(unseeded, name)
+				return nil
+			},
+		})
+	}
+
+	opts := RunOptions{ContinueOnError: true, SkipExecuted: true}
+	if err := RunAllWithOptions(db, nil, opts); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	// 003_products never succeeded, so the last executed seeder is 002_orders
+	if err := RollbackLast(1, db, nil, opts); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(unseeded) != 1 || unseeded[0] != "002_orders" {
+		t.Errorf("expected 002_orders to be rolled back, got %v", unseeded)
+	}
+
+	var latest SeedHistory
+	db.Where("seeder_name = ?", "002_orders").Order("id DESC").First(&latest)
+	if latest.Status != StatusRolledBack {
+		t.Errorf("expected rollback to be recorded, got status '%s'", latest.Status)
+	}
+
+	// The rolled back seeder runs again, the still executed one does not
+	RunAllWithOptions(db, nil, opts)
+	if seeded["001_users"] != 1 || seeded["002_orders"] != 2 {
+		t.Errorf("expected only the rolled back seeder to run again, got %v", seeded)
+	}
+}
+
+func TestRollbackAll_FailedDependentBlocksDependency(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	unseeded := []string{}
+	registerReversible("001_users", &unseeded)
+	Register(&mockReversibleSeeder{
+		mockSeeder: mockSeeder{name: "002_orders"},
+		deps:       []string{"001_users"},
+		unseedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			return errors.New("intentional failure")
+		},
+	})
+
+	err := RollbackAll(db, nil, RunOptions{ContinueOnError: true})
+
+	var seederErrs *SeederErrors
+	if !errors.As(err, &seederErrs) {
+		t.Fatalf("expected SeederErrors, got %T", err)
+	}
+	if len(seederErrs.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(seederErrs.Errors))
+	}
+	if seederErrs.Errors[1].Err.Error() != "dependent seeder 002_orders failed to roll back" {
+		t.Errorf("unexpected error for dependency: %v", seederErrs.Errors[1].Err)
+	}
+	if len(unseeded) != 0 {
+		t.Errorf("expected 001_users not to be rolled back, got %v", unseeded)
+	}
+}
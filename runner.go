@@ -0,0 +1,195 @@
+package gorm_seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Runner executes the seeders of a registry with a fixed set of options.
+// Runs share nothing but the registry, so a Runner is safe for concurrent use.
+type Runner struct {
+	registry *Registry
+	opts     RunOptions
+}
+
+// NewRunner creates a runner for the seeders of registry. A nil registry
+// selects the default registry.
+func NewRunner(registry *Registry, opts RunOptions) *Runner {
+	if registry == nil {
+		registry = DefaultRegistry()
+	}
+	return &Runner{
+		registry: registry,
+		opts:     opts,
+	}
+}
+
+// validate checks the registry before anything is executed
+func (r *Runner) validate() error {
+	return validateSeeders(r.registry.GetAll(), r.opts.StrictNames)
+}
+
+// RunAll executes the seeders selected by the range, pattern and tag filters
+// in dependency order, stopping when ctx is cancelled
+func (r *Runner) RunAll(ctx context.Context, db *gorm.DB, deps map[string]interface{}) error {
+	seeders, err := r.Selection()
+	if err != nil {
+		return err
+	}
+
+	return runSeeders(ctx, seeders, db, deps, r.opts, directionSeed)
+}
+
+// RunSpecific executes a specific seeder by name. The seeder runs even when
+// the history says it was executed before, and its failure is returned as a
+// *SeederError.
+func (r *Runner) RunSpecific(ctx context.Context, name string, db *gorm.DB, deps map[string]interface{}) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	seeder, err := r.registry.GetByName(name)
+	if err != nil {
+		return err
+	}
+
+	opts := r.opts
+	opts.TrackHistory = opts.TrackHistory || opts.SkipExecuted
+	opts.SkipExecuted = false
+	opts.ContinueOnError = false
+
+	err = runSeeders(ctx, []Seeder{seeder}, db, deps, opts, directionSeed)
+
+	// Errors raised before the seeder started, like a cancelled ctx, are
+	// still reported against it
+	var seederErr *SeederError
+	if err != nil && !errors.As(err, &seederErr) {
+		return &SeederError{
+			SeederName: seeder.Name(),
+			Err:        err,
+		}
+	}
+	return err
+}
+
+// RunWithDependencies executes a specific seeder by name after its transitive
+// dependencies. Tag filters do not apply.
+func (r *Runner) RunWithDependencies(ctx context.Context, name string, db *gorm.DB, deps map[string]interface{}) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	seeders, err := withDependencies(r.registry.GetAll(), name)
+	if err != nil {
+		return err
+	}
+
+	return runSeeders(ctx, seeders, db, deps, r.opts, directionSeed)
+}
+
+// RollbackAll unseeds the seeders selected by the range, pattern and tag
+// filters in reverse execution order, see the package-level RollbackAll
+func (r *Runner) RollbackAll(ctx context.Context, db *gorm.DB, deps map[string]interface{}) error {
+	return r.rollback(ctx, db, deps, -1)
+}
+
+// RollbackLast unseeds the last n seeders of the execution order, in reverse
+func (r *Runner) RollbackLast(ctx context.Context, n int, db *gorm.DB, deps map[string]interface{}) error {
+	if n <= 0 {
+		return fmt.Errorf("number of seeders to roll back must be positive, got %d", n)
+	}
+	return r.rollback(ctx, db, deps, n)
+}
+
+// RollbackSpecific unseeds a specific seeder by name
+func (r *Runner) RollbackSpecific(ctx context.Context, name string, db *gorm.DB, deps map[string]interface{}) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	seeder, err := r.registry.GetByName(name)
+	if err != nil {
+		return err
+	}
+	if !isReversible(seeder) {
+		return fmt.Errorf("seeder %s is not reversible", name)
+	}
+
+	return runSeeders(ctx, []Seeder{seeder}, db, deps, r.opts, directionUnseed)
+}
+
+// rollback unseeds up to limit seeders from the end of the execution order.
+// A negative limit rolls back all of them.
+func (r *Runner) rollback(ctx context.Context, db *gorm.DB, deps map[string]interface{}, limit int) error {
+	seeders, err := r.Selection()
+	if err != nil {
+		return err
+	}
+
+	return withLock(ctx, db, seeders, r.opts, func(db *gorm.DB) error {
+		history, err := newSeedHistory(db, r.opts)
+		if err != nil {
+			return err
+		}
+
+		selected := make([]Seeder, 0, len(seeders))
+		for i := len(seeders) - 1; i >= 0 && (limit < 0 || len(selected) < limit); i-- {
+			if history != nil && !history.isExecuted(seeders[i].Name()) {
+				continue
+			}
+			selected = append(selected, seeders[i])
+		}
+
+		return runWithHistory(ctx, selected, db, deps, r.opts, history, directionUnseed)
+	})
+}
+
+// RunAll executes the seeders of the registry in dependency order with default options (fail-fast)
+func (r *Registry) RunAll(db *gorm.DB, deps map[string]interface{}) error {
+	return r.RunAllWithOptions(db, deps, RunOptions{})
+}
+
+// RunAllWithOptions executes the seeders of the registry in dependency order with custom options
+func (r *Registry) RunAllWithOptions(db *gorm.DB, deps map[string]interface{}, opts RunOptions) error {
+	return r.RunAllContext(context.Background(), db, deps, opts)
+}
+
+// RunAllContext executes the seeders of the registry in dependency order,
+// stopping when ctx is cancelled
+func (r *Registry) RunAllContext(ctx context.Context, db *gorm.DB, deps map[string]interface{}, opts RunOptions) error {
+	return NewRunner(r, opts).RunAll(ctx, db, deps)
+}
+
+// RunSpecific executes a specific seeder of the registry by name
+func (r *Registry) RunSpecific(name string, db *gorm.DB, deps map[string]interface{}) error {
+	return r.RunSpecificContext(context.Background(), name, db, deps)
+}
+
+// RunSpecificContext executes a specific seeder of the registry by name with a context
+func (r *Registry) RunSpecificContext(ctx context.Context, name string, db *gorm.DB, deps map[string]interface{}) error {
+	return NewRunner(r, RunOptions{}).RunSpecific(ctx, name, db, deps)
+}
+
+// RunSpecificWithDependencies executes a specific seeder of the registry by
+// name after its transitive dependencies, stopping at the first failure
+func (r *Registry) RunSpecificWithDependencies(name string, db *gorm.DB, deps map[string]interface{}) error {
+	return NewRunner(r, RunOptions{}).RunWithDependencies(context.Background(), name, db, deps)
+}
+
+// RollbackAll unseeds the seeders of the registry in reverse execution order
+func (r *Registry) RollbackAll(db *gorm.DB, deps map[string]interface{}, opts RunOptions) error {
+	return NewRunner(r, opts).RollbackAll(context.Background(), db, deps)
+}
+
+// RollbackLast unseeds the last n seeders of the registry's execution order
+func (r *Registry) RollbackLast(n int, db *gorm.DB, deps map[string]interface{}, opts RunOptions) error {
+	return NewRunner(r, opts).RollbackLast(context.Background(), n, db, deps)
+}
+
+// RollbackSpecific unseeds a specific seeder of the registry by name
+func (r *Registry) RollbackSpecific(name string, db *gorm.DB, deps map[string]interface{}, opts RunOptions) error {
+	return NewRunner(r, opts).RollbackSpecific(context.Background(), name, db, deps)
+}
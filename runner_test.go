@@ -0,0 +1,154 @@
+package gorm_seed
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestRegistry_IndependentOfDefault(t *testing.T) {
+	Clear()
+	Register(&mockSeeder{name: "default_seeder"})
+
+	reg := NewRegistry()
+	reg.Register(&mockSeeder{name: "002_products"})
+	reg.Register(&mockSeeder{name: "001_users"})
+
+	if Count() != 1 {
+		t.Errorf("expected default registry to keep 1 seeder, got %d", Count())
+	}
+	if reg.Count() != 2 {
+		t.Errorf("expected 2 seeders in registry, got %d", reg.Count())
+	}
+
+	all := reg.GetAll()
+	if all[0].Name() != "001_users" || all[1].Name() != "002_products" {
+		t.Errorf("expected seeders sorted by name, got %v", seederNames(all))
+	}
+
+	if _, err := reg.GetByName("default_seeder"); err == nil {
+		t.Error("expected seeder of the default registry not to be found, got nil")
+	}
+
+	reg.Clear()
+	if reg.Count() != 0 || Count() != 1 {
+		t.Errorf("expected Clear to only affect its registry, got %d and %d", reg.Count(), Count())
+	}
+}
+
+func TestRegistry_ZeroValue(t *testing.T) {
+	var reg Registry
+	reg.Register(&mockSeeder{name: "001_users"})
+
+	if err := reg.RunAll(setupTestDB(t), nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
+
+func TestRegistry_ParallelTests(t *testing.T) {
+	for _, name := range []string{"first", "second", "third"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			executed := []string{}
+			reg := NewRegistry()
+			reg.Register(&mockSeeder{
+				name: name,
+				seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+					executed = append(executed, name)
+					return nil
+				},
+			})
+
+			if err := reg.RunAll(setupTestDB(t), nil); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if len(executed) != 1 || executed[0] != name {
+				t.Errorf("expected only %s to run, got %v", name, executed)
+			}
+		})
+	}
+}
+
+func TestRunner_RunAll(t *testing.T) {
+	db := setupTestDB(t)
+
+	reg := NewRegistry()
+	reg.Register(&mockSeeder{name: "001_users"})
+	reg.Register(&mockSeeder{
+		name: "002_failing",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			return errors.New("intentional failure")
+		},
+	})
+	reg.Register(&mockSeeder{name: "003_products"})
+
+	completed := []string{}
+	runner := NewRunner(reg, RunOptions{
+		ContinueOnError: true,
+		OnSeederComplete: func(name string) {
+			completed = append(completed, name)
+		},
+	})
+
+	err := runner.RunAll(context.Background(), db, nil)
+
+	var seederErrs *SeederErrors
+	if !errors.As(err, &seederErrs) || len(seederErrs.Errors) != 1 {
+		t.Fatalf("expected 1 seeder error, got %v", err)
+	}
+	if len(completed) != 2 {
+		t.Errorf("expected 2 seeders to complete, got %v", completed)
+	}
+}
+
+func TestRunner_RunSpecificIgnoresSkipExecuted(t *testing.T) {
+	db := setupTestDB(t)
+
+	runs := 0
+	reg := NewRegistry()
+	reg.Register(&mockSeeder{
+		name: "001_users",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			runs++
+			return nil
+		},
+	})
+
+	runner := NewRunner(reg, RunOptions{SkipExecuted: true})
+	for i := 0; i < 2; i++ {
+		if err := runner.RunSpecific(context.Background(), "001_users", db, nil); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+	if runs != 2 {
+		t.Errorf("expected seeder to run twice, got %d", runs)
+	}
+
+	var count int64
+	db.Model(&SeedHistory{}).Where("seeder_name = ?", "001_users").Count(&count)
+	if count != 2 {
+		t.Errorf("expected 2 history records, got %d", count)
+	}
+
+	// The history now skips it in a full run
+	if err := runner.RunAll(context.Background(), db, nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if runs != 2 {
+		t.Errorf("expected executed seeder to be skipped, got %d runs", runs)
+	}
+}
+
+func TestRunner_NilRegistryUsesDefault(t *testing.T) {
+	Clear()
+	Register(&mockSeeder{name: "001_users"})
+
+	runner := NewRunner(nil, RunOptions{})
+	if err := runner.RunSpecific(context.Background(), "001_users", setupTestDB(t), nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
@@ -1,9 +1,11 @@
 package gorm_seed
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -16,32 +18,49 @@ type Seeder interface {
 	Seed(db *gorm.DB, deps map[string]interface{}) error
 }
 
-// SeederRegistry holds all registered seeders
-type SeederRegistry struct {
+// Registry holds a set of seeders. Each registry is independent, so tests
+// and applications can keep several of them side by side. The zero value is
+// an empty registry ready to use.
+type Registry struct {
 	mu      sync.RWMutex
 	seeders []Seeder
 }
 
-// registry is the global seeder registry
-var registry = &SeederRegistry{
-	seeders: make([]Seeder, 0),
+// SeederRegistry is the former name of Registry
+//
+// Deprecated: use Registry
+type SeederRegistry = Registry
+
+// NewRegistry creates an empty seeder registry
+func NewRegistry() *Registry {
+	return &Registry{
+		seeders: make([]Seeder, 0),
+	}
 }
 
-// Register adds a seeder to the global registry in a thread-safe manner
-func Register(seeder Seeder) {
-	registry.mu.Lock()
-	defer registry.mu.Unlock()
-	registry.seeders = append(registry.seeders, seeder)
+// registry is the default registry used by the package-level functions
+var registry = NewRegistry()
+
+// DefaultRegistry returns the registry used by the package-level functions
+func DefaultRegistry() *Registry {
+	return registry
 }
 
-// GetAll returns all registered seeders sorted by name
-func GetAll() []Seeder {
-	registry.mu.RLock()
-	defer registry.mu.RUnlock()
+// Register adds a seeder to the registry in a thread-safe manner
+func (r *Registry) Register(seeder Seeder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seeders = append(r.seeders, seeder)
+}
+
+// GetAll returns all seeders of the registry sorted by name
+func (r *Registry) GetAll() []Seeder {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
 	// Create a copy to avoid race conditions
-	seeders := make([]Seeder, len(registry.seeders))
-	copy(seeders, registry.seeders)
+	seeders := make([]Seeder, len(r.seeders))
+	copy(seeders, r.seeders)
 
 	// Sort seeders by name to ensure consistent ordering
 	sort.Slice(seeders, func(i, j int) bool {
@@ -50,12 +69,12 @@ func GetAll() []Seeder {
 	return seeders
 }
 
-// GetByName finds a seeder by its name
-func GetByName(name string) (Seeder, error) {
-	registry.mu.RLock()
-	defer registry.mu.RUnlock()
+// GetByName finds a seeder of the registry by its name
+func (r *Registry) GetByName(name string) (Seeder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-	for _, seeder := range registry.seeders {
+	for _, seeder := range r.seeders {
 		if seeder.Name() == name {
 			return seeder, nil
 		}
@@ -63,6 +82,35 @@ func GetByName(name string) (Seeder, error) {
 	return nil, fmt.Errorf("seeder not found: %s", name)
 }
 
+// Clear removes all seeders from the registry
+func (r *Registry) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seeders = make([]Seeder, 0)
+}
+
+// Count returns the number of seeders in the registry
+func (r *Registry) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.seeders)
+}
+
+// Register adds a seeder to the default registry in a thread-safe manner
+func Register(seeder Seeder) {
+	registry.Register(seeder)
+}
+
+// GetAll returns all registered seeders sorted by name
+func GetAll() []Seeder {
+	return registry.GetAll()
+}
+
+// GetByName finds a registered seeder by its name
+func GetByName(name string) (Seeder, error) {
+	return registry.GetByName(name)
+}
+
 // RunOptions configures how seeders should be executed
 type RunOptions struct {
 	// ContinueOnError determines whether to continue running seeders if one fails
@@ -73,15 +121,119 @@ type RunOptions struct {
 	OnSeederComplete func(name string)
 	// OnSeederError is called when a seeder fails (optional)
 	OnSeederError func(name string, err error)
+	// OnSeederSkip is called when a seeder is skipped because it already ran,
+	// or because it is not reversible during a rollback (optional). Seeders a
+	// failed or cancelled run never reached are only reported to Observers,
+	// as skip events with the reason ReasonEarlierFailure or ReasonCancelled.
+	OnSeederSkip func(name string)
+	// OnSeederRetry is called when a failed attempt of a seeder is retried (optional)
+	OnSeederRetry func(name string, attempt int, err error)
+
+	// TrackHistory records every seeder run in the history table
+	TrackHistory bool
+	// SkipExecuted skips seeders with a successful run in the history table,
+	// unless their RunPolicy asks for another run (implies TrackHistory)
+	SkipExecuted bool
+	// HistoryTable overrides the history table name (default: seed_history)
+	HistoryTable string
+
+	// SeederTimeout limits how long each seeder may run (optional, 0 means no limit)
+	SeederTimeout time.Duration
+
+	// Transaction controls whether seeders run inside database transactions
+	Transaction TransactionMode
+
+	// Retry runs seeders again after transient failures (optional)
+	Retry RetryPolicy
+
+	// RandomSeed seeds the faker each seeder gets from Faker(db). Every
+	// seeder's faker is derived from RandomSeed and the seeder's name, so the
+	// same seed generates the same data. 0 picks a random seed, reported in
+	// Event.RandomSeed.
+	RandomSeed int64
+
+	// Concurrency is the maximum number of seeders running at the same time.
+	// Seeders only run concurrently when neither depends on the other.
+	// Values below 2 run seeders one after another.
+	Concurrency int
+
+	// Databases holds named connections for DatabaseSeeders (optional).
+	// Seeders without a database name run on the db passed to the runner,
+	// which also holds the history and lock tables.
+	Databases map[string]*gorm.DB
+
+	// Container holds typed dependencies for ContainerSeeders (optional).
+	// When nil, it is built from the deps map passed to the runner; when set
+	// and the deps map is nil, map-based seeders receive its named entries.
+	Container *Container
+
+	// IncludeTags runs only seeders with at least one of these tags (optional)
+	IncludeTags []string
+	// ExcludeTags skips seeders with any of these tags (optional)
+	ExcludeTags []string
+
+	// From runs only the named seeder and those after it in execution order (optional)
+	From string
+	// To runs only the named seeder and those before it in execution order (optional)
+	To string
+	// Only runs only seeders whose name matches one of these patterns: globs
+	// like "*_users", or regular expressions wrapped in slashes like
+	// "/^00[1-3]_/" (optional)
+	Only []string
+	// Except skips seeders whose name matches one of these patterns (optional)
+	Except []string
+
+	// StrictNames makes the runner reject seeder names that do not follow the
+	// NNN_name or timestamp convention, see ValidateName. Empty and duplicate
+	// names are always rejected.
+	StrictNames bool
+
+	// DryRun runs each seeder against a gorm dry-run session: the SQL is built
+	// and reported through OnSeederSQL but never executed. The history table
+	// is read but not written, and transactions are not used.
+	DryRun bool
+	// OnSeederSQL is called with the statements of each seeder in a dry run (optional)
+	OnSeederSQL func(name string, statements []string)
+
+	// Observers receive a structured event for every step of the run, see
+	// Event. The OnSeeder* callbacks are delivered the same way.
+	Observers []Observer
+	// RunID identifies the run in events (optional, generated when empty)
+	RunID string
+
+	// Lock makes the run hold a database lock, so runs from several processes
+	// against the same database never overlap. Postgres and MySQL use
+	// advisory locks, other databases a row in LockTable. Dry runs do not lock.
+	Lock bool
+	// LockTimeout is how long to wait for the lock (default: DefaultLockTimeout)
+	LockTimeout time.Duration
+	// LockName identifies the lock; runs using different names do not exclude
+	// each other (default: DefaultLockName)
+	LockName string
+	// LockTable overrides the lock table name (default: seed_lock)
+	LockTable string
+
+	// events delivers the events of the current run
+	events *eventEmitter
 }
 
-// SeederError represents an error that occurred while running a seeder
+// SeederError represents an error that occurred while running a seeder.
+// SeederName is empty for a failure of the run as a whole, such as a
+// *LockError.
 type SeederError struct {
 	SeederName string
 	Err        error
+	// Attempts is how often the seeder ran, more than 1 when it was retried
+	Attempts int
 }
 
 func (e *SeederError) Error() string {
+	if e.SeederName == "" {
+		return e.Err.Error()
+	}
+	if e.Attempts > 1 {
+		return fmt.Sprintf("seeder %s failed after %d attempts: %v", e.SeederName, e.Attempts, e.Err)
+	}
 	return fmt.Sprintf("seeder %s failed: %v", e.SeederName, e.Err)
 }
 
@@ -92,16 +244,34 @@ func (e *SeederError) Unwrap() error {
 // SeederErrors represents multiple seeder errors
 type SeederErrors struct {
 	Errors []*SeederError
+	// RolledBack is true when every change made by the run was rolled back
+	RolledBack bool
 }
 
 func (e *SeederErrors) Error() string {
-	if len(e.Errors) == 0 {
-		return "no errors"
+	var msg string
+	switch len(e.Errors) {
+	case 0:
+		msg = "no errors"
+	case 1:
+		msg = e.Errors[0].Error()
+	default:
+		msg = fmt.Sprintf("%d seeders failed: %s (and %d more)", len(e.Errors), e.Errors[0].SeederName, len(e.Errors)-1)
 	}
-	if len(e.Errors) == 1 {
-		return e.Errors[0].Error()
+
+	if e.RolledBack {
+		msg += " (all changes rolled back)"
 	}
-	return fmt.Sprintf("%d seeders failed: %s (and %d more)", len(e.Errors), e.Errors[0].SeederName, len(e.Errors)-1)
+	return msg
+}
+
+// Unwrap returns the individual seeder errors
+func (e *SeederErrors) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
 }
 
 // Add adds a seeder error to the collection
@@ -117,43 +287,112 @@ func (e *SeederErrors) HasErrors() bool {
 	return len(e.Errors) > 0
 }
 
-// RunAll executes all registered seeders in order with default options (fail-fast)
+// RunAll executes all registered seeders in dependency order with default options (fail-fast)
 func RunAll(db *gorm.DB, deps map[string]interface{}) error {
-	return RunAllWithOptions(db, deps, RunOptions{
-		ContinueOnError: false,
-	})
+	return registry.RunAll(db, deps)
 }
 
-// RunAllWithOptions executes all registered seeders in order with custom options
+// RunAllWithOptions executes all registered seeders in dependency order with custom options
 func RunAllWithOptions(db *gorm.DB, deps map[string]interface{}, opts RunOptions) error {
-	seeders := GetAll()
-	errors := &SeederErrors{}
+	return registry.RunAllWithOptions(db, deps, opts)
+}
 
-	for _, seeder := range seeders {
-		if opts.OnSeederStart != nil {
-			opts.OnSeederStart(seeder.Name())
+// direction selects whether the runner seeds or unseeds
+type direction int
+
+const (
+	directionSeed direction = iota
+	directionUnseed
+)
+
+// runSeeders executes the given seeders in order while holding the run lock,
+// stopping before the next seeder once ctx is cancelled
+func runSeeders(ctx context.Context, seeders []Seeder, db *gorm.DB, deps map[string]interface{}, opts RunOptions, dir direction) error {
+	return withLock(ctx, db, seeders, opts, func(db *gorm.DB) error {
+		history, err := newSeedHistory(db, opts)
+		if err != nil {
+			return err
 		}
 
-		if err := seeder.Seed(db, deps); err != nil {
-			seederErr := &SeederError{
-				SeederName: seeder.Name(),
-				Err:        err,
-			}
+		return runWithHistory(ctx, seeders, db, deps, opts, history, dir)
+	})
+}
 
-			if opts.OnSeederError != nil {
-				opts.OnSeederError(seeder.Name(), err)
-			}
+// runWithHistory executes the seeders using an already loaded history
+func runWithHistory(ctx context.Context, seeders []Seeder, db *gorm.DB, deps map[string]interface{}, opts RunOptions, history *seedHistory, dir direction) error {
+	deps, opts = bridgeDependencies(deps, opts)
 
-			if !opts.ContinueOnError {
-				return seederErr
-			}
+	if opts.RandomSeed == 0 {
+		seed, err := newRandomSeed()
+		if err != nil {
+			return err
+		}
+		opts.RandomSeed = seed
+	}
 
-			errors.Add(seeder.Name(), err)
+	events, err := newEventEmitter(opts)
+	if err != nil {
+		return err
+	}
+	opts.events = events
+	ctx = withFixtureRecords(ctx)
+	if err := checkDatabases(seeders, opts); err != nil {
+		return err
+	}
+	if err := registerRowCallbacks(db); err != nil {
+		return fmt.Errorf("failed to register row callbacks: %w", err)
+	}
+	for _, named := range opts.Databases {
+		if named == nil {
 			continue
 		}
+		if err := registerRowCallbacks(named); err != nil {
+			return fmt.Errorf("failed to register row callbacks: %w", err)
+		}
+	}
 
-		if opts.OnSeederComplete != nil {
-			opts.OnSeederComplete(seeder.Name())
+	if opts.DryRun {
+		opts.Transaction = TransactionNone
+	}
+
+	if opts.Transaction == TransactionAll {
+		if opts.Concurrency > 1 {
+			return fmt.Errorf("concurrency is not supported with TransactionAll")
+		}
+		if usesNamedDatabase(seeders) {
+			return fmt.Errorf("seeders on named databases are not supported with TransactionAll")
+		}
+		return runInTransaction(ctx, seeders, db, deps, opts, history, dir)
+	}
+
+	if opts.Concurrency > 1 {
+		return executeParallel(ctx, seeders, db, deps, opts, history, dir)
+	}
+
+	return executeSeeders(ctx, seeders, db, deps, opts, history, dir)
+}
+
+// executeSeeders runs the seeders one after another on db
+func executeSeeders(ctx context.Context, seeders []Seeder, db *gorm.DB, deps map[string]interface{}, opts RunOptions, history *seedHistory, dir direction) error {
+	errors := &SeederErrors{}
+	failed := make(map[string]bool)
+
+	for i, seeder := range seeders {
+		if err := ctx.Err(); err != nil {
+			skipRemaining(seeders[i:], ReasonCancelled, opts, history, dir)
+			return err
+		}
+
+		// blockedBy is only non-nil with ContinueOnError
+		blocked := blockedBy(seeder, seeders, failed, dir)
+		if err := executeSeeder(ctx, seeder, blocked, db, deps, opts, history, dir); err != nil {
+			if !opts.ContinueOnError {
+				skipRemaining(seeders[i+1:], ReasonEarlierFailure, opts, history, dir)
+				return err
+			}
+
+			errors.Errors = append(errors.Errors, err)
+			failed[seeder.Name()] = true
 		}
 	}
 
@@ -164,33 +403,158 @@ func RunAllWithOptions(db *gorm.DB, deps map[string]interface{}, opts RunOptions
 	return nil
 }
 
-// RunSpecific executes a specific seeder by name
-func RunSpecific(name string, db *gorm.DB, deps map[string]interface{}) error {
-	seeder, err := GetByName(name)
-	if err != nil {
-		return err
+// executeSeeder runs a single seeder, retrying it according to opts.Retry,
+// and reports it through the events of opts. A non-nil blocked error fails
+// the seeder without running it.
+func executeSeeder(ctx context.Context, seeder Seeder, blocked error, db *gorm.DB, deps map[string]interface{}, opts RunOptions, history *seedHistory, dir direction) *SeederError {
+	event := Event{
+		SeederName: seeder.Name(),
+		Rollback:   dir == directionUnseed,
+	}
+
+	if reason := skipReason(seeder, opts, history, dir); reason != "" {
+		event.Type = EventSkip
+		event.Reason = reason
+		opts.events.emit(event)
+		return nil
 	}
 
-	if err := seeder.Seed(db, deps); err != nil {
+	event.Type = EventStart
+	opts.events.emit(event)
+
+	startedAt := time.Now()
+	err := blocked
+	if err == nil {
+		err = runWithRetries(ctx, seeder, db, deps, opts, dir, &event)
+		if recordErr := history.record(seeder.Name(), versionOf(seeder), startedAt, dir.status(), err); recordErr != nil && err == nil {
+			err = recordErr
+		}
+	}
+	event.Duration = time.Since(startedAt)
+
+	if err != nil {
+		event.Type = EventError
+		event.Err = err
+		opts.events.emit(event)
 		return &SeederError{
 			SeederName: seeder.Name(),
 			Err:        err,
+			Attempts:   event.Attempt,
 		}
 	}
 
+	event.Type = EventFinish
+	opts.events.emit(event)
 	return nil
 }
 
+// skipRemaining emits a skip event for each seeder the run stopped before,
+// so observers see every seeder of the selection. reason explains why the run
+// stopped, unless the seeder would have been skipped anyway.
+func skipRemaining(seeders []Seeder, reason string, opts RunOptions, history *seedHistory, dir direction) {
+	for _, seeder := range seeders {
+		event := Event{
+			Type:       EventSkip,
+			SeederName: seeder.Name(),
+			Rollback:   dir == directionUnseed,
+			Reason:     skipReason(seeder, opts, history, dir),
+		}
+		if event.Reason == "" {
+			event.Reason = reason
+		}
+		opts.events.emit(event)
+	}
+}
+
+// runWithRetries runs a seeder until it succeeds or opts.Retry gives up,
+// emitting a retry event for every failed attempt that is retried. event
+// receives the attempt count and statistics of the last attempt.
+func runWithRetries(ctx context.Context, seeder Seeder, db *gorm.DB, deps map[string]interface{}, opts RunOptions, dir direction, event *Event) error {
+	for attempt := 1; ; attempt++ {
+		startedAt := time.Now()
+		stats, err := runSeeder(ctx, seeder, db, deps, opts, dir)
+
+		event.Attempt = attempt
+		event.RowsAffected = stats.rows
+		event.Statements = stats.statements
+		if err == nil || !opts.Retry.retryable(attempt, err) {
+			return err
+		}
+
+		retry := *event
+		retry.Type = EventRetry
+		retry.Duration = time.Since(startedAt)
+		retry.Err = err
+		opts.events.emit(retry)
+
+		if err := opts.Retry.wait(ctx, attempt+1); err != nil {
+			return err
+		}
+	}
+}
+
+// skipReason reports why a seeder does not run, or "" when it runs
+func skipReason(seeder Seeder, opts RunOptions, history *seedHistory, dir direction) string {
+	if dir == directionSeed && opts.SkipExecuted && !needsRun(seeder, history) {
+		return ReasonExecuted
+	}
+	if dir == directionUnseed && !isReversible(seeder) {
+		return ReasonNotReversible
+	}
+	return ""
+}
+
+// status returns the history status recorded when a seeder succeeds
+func (d direction) status() string {
+	if d == directionUnseed {
+		return StatusRolledBack
+	}
+	return StatusSuccess
+}
+
+// blockedBy reports why a seeder must not run after earlier failures: a
+// seeder never runs on top of a failed dependency, and is never unseeded while
+// a seeder depending on it failed to unseed
+func blockedBy(seeder Seeder, seeders []Seeder, failed map[string]bool, dir direction) error {
+	if dir == directionSeed {
+		for _, dep := range dependenciesOf(seeder) {
+			if failed[dep] {
+				return fmt.Errorf("dependency %s failed", dep)
+			}
+		}
+		return nil
+	}
+
+	for _, other := range seeders {
+		if !failed[other.Name()] {
+			continue
+		}
+		for _, dep := range dependenciesOf(other) {
+			if dep == seeder.Name() {
+				return fmt.Errorf("dependent seeder %s failed to roll back", other.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// RunSpecific executes a specific seeder by name
+func RunSpecific(name string, db *gorm.DB, deps map[string]interface{}) error {
+	return registry.RunSpecific(name, db, deps)
+}
+
+// RunSpecificWithDependencies executes a specific seeder by name after its
+// transitive dependencies, stopping at the first failure
+func RunSpecificWithDependencies(name string, db *gorm.DB, deps map[string]interface{}) error {
+	return registry.RunSpecificWithDependencies(name, db, deps)
+}
+
 // Clear removes all registered seeders (useful for testing)
 func Clear() {
-	registry.mu.Lock()
-	defer registry.mu.Unlock()
-	registry.seeders = make([]Seeder, 0)
+	registry.Clear()
 }
 
 // Count returns the number of registered seeders
 func Count() int {
-	registry.mu.RLock()
-	defer registry.mu.RUnlock()
-	return len(registry.seeders)
+	return registry.Count()
 }
@@ -550,3 +550,18 @@ func TestSeederErrors_HasErrors(t *testing.T) {
 		t.Error("expected HasErrors to return true after adding error")
 	}
 }
+
+func TestSeederErrors_RolledBack(t *testing.T) {
+	errs := &SeederErrors{RolledBack: true}
+	errs.Add("seeder1", errors.New("error1"))
+
+	expected := "seeder seeder1 failed: error1 (all changes rolled back)"
+	if errs.Error() != expected {
+		t.Errorf("expected '%s', got '%s'", expected, errs.Error())
+	}
+
+	var seederErr *SeederError
+	if !errors.As(errs, &seederErr) || seederErr.SeederName != "seeder1" {
+		t.Errorf("expected to unwrap SeederError, got %v", seederErr)
+	}
+}
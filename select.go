@@ -0,0 +1,134 @@
+package gorm_seed
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// namePattern matches seeder names against a glob like "*_users" or, when
+// wrapped in slashes like "/^00[1-3]_/", a regular expression
+type namePattern struct {
+	glob string
+	re   *regexp.Regexp
+}
+
+// parseNamePattern parses a pattern of Only or Except
+func parseNamePattern(pattern string) (namePattern, error) {
+	if len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return namePattern{}, fmt.Errorf("invalid seeder pattern %q: %w", pattern, err)
+		}
+		return namePattern{re: re}, nil
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return namePattern{}, fmt.Errorf("invalid seeder pattern %q: %w", pattern, err)
+	}
+	return namePattern{glob: pattern}, nil
+}
+
+func (p namePattern) match(name string) bool {
+	if p.re != nil {
+		return p.re.MatchString(name)
+	}
+	matched, _ := path.Match(p.glob, name)
+	return matched
+}
+
+// parseNamePatterns parses all patterns of Only or Except
+func parseNamePatterns(patterns []string) ([]namePattern, error) {
+	parsed := make([]namePattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		p, err := parseNamePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, p)
+	}
+	return parsed, nil
+}
+
+// matchesAny reports whether name matches at least one of the patterns
+func matchesAny(name string, patterns []namePattern) bool {
+	for _, p := range patterns {
+		if p.match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// indexOfSeeder returns the position of the named seeder in seeders
+func indexOfSeeder(seeders []Seeder, name string) (int, error) {
+	for i, seeder := range seeders {
+		if seeder.Name() == name {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("seeder not found: %s", name)
+}
+
+// selectSeeders keeps the seeders of the execution order selected by the
+// range, pattern and tag filters in opts, preserving their order. From and To
+// refer to positions in the full execution order, so they may name a seeder
+// that the other filters leave out.
+func selectSeeders(seeders []Seeder, opts RunOptions) ([]Seeder, error) {
+	first, last := 0, len(seeders)-1
+	if opts.From != "" {
+		i, err := indexOfSeeder(seeders, opts.From)
+		if err != nil {
+			return nil, err
+		}
+		first = i
+	}
+	if opts.To != "" {
+		i, err := indexOfSeeder(seeders, opts.To)
+		if err != nil {
+			return nil, err
+		}
+		last = i
+	}
+	if opts.From != "" && opts.To != "" && first > last {
+		return nil, fmt.Errorf("seeder %s runs after %s", opts.From, opts.To)
+	}
+
+	only, err := parseNamePatterns(opts.Only)
+	if err != nil {
+		return nil, err
+	}
+	except, err := parseNamePatterns(opts.Except)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]Seeder, 0, last-first+1)
+	for _, seeder := range seeders[first : last+1] {
+		if len(only) > 0 && !matchesAny(seeder.Name(), only) {
+			continue
+		}
+		if matchesAny(seeder.Name(), except) {
+			continue
+		}
+		selected = append(selected, seeder)
+	}
+	return filterByTags(selected, opts), nil
+}
+
+// Selection returns the seeders RunAll considers, in execution order, without
+// running them. Seeders that would be skipped because of the history are
+// included.
+func (r *Runner) Selection() ([]Seeder, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	seeders, err := r.registry.ExecutionOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	return selectSeeders(seeders, r.opts)
+}
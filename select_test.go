@@ -0,0 +1,129 @@
+package gorm_seed
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRunAllWithOptions_FromTo(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	executed := []string{}
+	registerTagged(&executed)
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		From: "002_demo_users",
+		To:   "003_bulk_orders",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := []string{"002_demo_users", "003_bulk_orders"}
+	if strings.Join(executed, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v to execute, got %v", expected, executed)
+	}
+}
+
+func TestRunAllWithOptions_OnlyExcept(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	executed := []string{}
+	registerTagged(&executed)
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Only:   []string{"*_demo_*", "/^00[34]_/"},
+		Except: []string{"004_*"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := []string{"002_demo_users", "003_bulk_orders"}
+	if strings.Join(executed, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v to execute, got %v", expected, executed)
+	}
+}
+
+func TestRunner_SelectionCombinesFilters(t *testing.T) {
+	Clear()
+
+	executed := []string{}
+	registerTagged(&executed)
+
+	// The range starts at a seeder that the tag filter leaves out
+	seeders, err := NewRunner(nil, RunOptions{
+		From:        "001_countries",
+		Except:      []string{"002_*"},
+		IncludeTags: []string{"demo"},
+	}).Selection()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if names := strings.Join(seederNames(seeders), ","); names != "003_bulk_orders" {
+		t.Errorf("expected only 003_bulk_orders, got %s", names)
+	}
+	if len(executed) != 0 {
+		t.Errorf("expected Selection not to run seeders, got %v", executed)
+	}
+}
+
+func TestRunner_SelectionErrors(t *testing.T) {
+	Clear()
+
+	executed := []string{}
+	registerTagged(&executed)
+
+	tests := []struct {
+		name string
+		opts RunOptions
+		want string
+	}{
+		{"unknown from", RunOptions{From: "009_missing"}, "seeder not found: 009_missing"},
+		{"unknown to", RunOptions{To: "009_missing"}, "seeder not found: 009_missing"},
+		{"reversed range", RunOptions{From: "003_bulk_orders", To: "001_countries"}, "runs after"},
+		{"invalid glob", RunOptions{Only: []string{"[001"}}, "invalid seeder pattern"},
+		{"invalid regexp", RunOptions{Except: []string{"/(001/"}}, "invalid seeder pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRunner(nil, tt.opts).Selection()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+
+			err = NewRunner(nil, tt.opts).RunAll(context.Background(), setupTestDB(t), nil)
+			if err == nil {
+				t.Error("expected RunAll to fail")
+			}
+		})
+	}
+
+	if len(executed) != 0 {
+		t.Errorf("expected no seeders to run, got %v", executed)
+	}
+}
+
+func TestRollbackAll_FromTo(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	unseeded := []string{}
+	registerReversible("001_users", &unseeded)
+	registerReversible("002_orders", &unseeded)
+	registerReversible("003_invoices", &unseeded)
+
+	if err := RollbackAll(db, nil, RunOptions{To: "002_orders"}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := []string{"002_orders", "001_users"}
+	if strings.Join(unseeded, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v to be rolled back, got %v", expected, unseeded)
+	}
+}
@@ -0,0 +1,49 @@
+package gorm_seed
+
+// TaggedSeeder is implemented by seeders that belong to groups such as
+// "reference", "demo" or "load-test"
+type TaggedSeeder interface {
+	Seeder
+	// Tags returns the groups the seeder belongs to
+	Tags() []string
+}
+
+// tagsOf returns the declared tags of a seeder
+func tagsOf(seeder Seeder) []string {
+	if tagged, ok := seeder.(TaggedSeeder); ok {
+		return tagged.Tags()
+	}
+	return nil
+}
+
+// hasAnyTag reports whether the seeder has at least one of the given tags
+func hasAnyTag(seeder Seeder, tags []string) bool {
+	for _, tag := range tagsOf(seeder) {
+		for _, want := range tags {
+			if tag == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterByTags keeps the seeders selected by the tag filters in opts,
+// preserving their order
+func filterByTags(seeders []Seeder, opts RunOptions) []Seeder {
+	if len(opts.IncludeTags) == 0 && len(opts.ExcludeTags) == 0 {
+		return seeders
+	}
+
+	filtered := make([]Seeder, 0, len(seeders))
+	for _, seeder := range seeders {
+		if len(opts.IncludeTags) > 0 && !hasAnyTag(seeder, opts.IncludeTags) {
+			continue
+		}
+		if hasAnyTag(seeder, opts.ExcludeTags) {
+			continue
+		}
+		filtered = append(filtered, seeder)
+	}
+	return filtered
+}
@@ -0,0 +1,131 @@
+package gorm_seed
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// mockTaggedSeeder is a test seeder that declares tags
+type mockTaggedSeeder struct {
+	mockSeeder
+	tags []string
+}
+
+func (m *mockTaggedSeeder) Tags() []string {
+	return m.tags
+}
+
+// registerTagged registers tagged seeders that append their name to executed
+func registerTagged(executed *[]string) {
+	record := func(name string) func(db *gorm.DB, deps map[string]interface{}) error {
+		return func(db *gorm.DB, deps map[string]interface{}) error {
+			*executed = append(*executed, name)
+			return nil
+		}
+	}
+
+	Register(&mockTaggedSeeder{mockSeeder: mockSeeder{name: "001_countries", seedFunc: record("001_countries")}, tags: []string{"reference"}})
+	Register(&mockTaggedSeeder{mockSeeder: mockSeeder{name: "002_demo_users", seedFunc: record("002_demo_users")}, tags: []string{"demo"}})
+	Register(&mockTaggedSeeder{mockSeeder: mockSeeder{name: "003_bulk_orders", seedFunc: record("003_bulk_orders")}, tags: []string{"demo", "load-test"}})
+	Register(&mockSeeder{name: "004_untagged", seedFunc: record("004_untagged")})
+}
+
+func TestRunAllWithOptions_IncludeTags(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	executed := []string{}
+	registerTagged(&executed)
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		IncludeTags: []string{"reference", "demo"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := []string{"001_countries", "002_demo_users", "003_bulk_orders"}
+	if strings.Join(executed, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v to execute, got %v", expected, executed)
+	}
+}
+
+func TestRunAllWithOptions_ExcludeTags(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	executed := []string{}
+	registerTagged(&executed)
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		ExcludeTags: []string{"load-test"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := []string{"001_countries", "002_demo_users", "004_untagged"}
+	if strings.Join(executed, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v to execute, got %v", expected, executed)
+	}
+}
+
+func TestRunAllWithOptions_IncludeAndExcludeTags(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	executed := []string{}
+	registerTagged(&executed)
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		IncludeTags: []string{"demo"},
+		ExcludeTags: []string{"load-test"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(executed) != 1 || executed[0] != "002_demo_users" {
+		t.Errorf("expected only 002_demo_users to execute, got %v", executed)
+	}
+}
+
+// mockTaggedReversibleSeeder is a test seeder that is both tagged and reversible
+type mockTaggedReversibleSeeder struct {
+	mockReversibleSeeder
+	tags []string
+}
+
+func (m *mockTaggedReversibleSeeder) Tags() []string {
+	return m.tags
+}
+
+func TestRollbackAll_Tags(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	unseeded := []string{}
+	for name, tag := range map[string]string{"001_countries": "reference", "002_demo_users": "demo"} {
+		name := name
+		Register(&mockTaggedReversibleSeeder{
+			mockReversibleSeeder: mockReversibleSeeder{
+				mockSeeder: mockSeeder{name: name},
+				unseedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+					unseeded = append(unseeded, name)
+					return nil
+				},
+			},
+			tags: []string{tag},
+		})
+	}
+
+	if err := RollbackAll(db, nil, RunOptions{IncludeTags: []string{"demo"}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(unseeded) != 1 || unseeded[0] != "002_demo_users" {
+		t.Errorf("expected only 002_demo_users to be rolled back, got %v", unseeded)
+	}
+}
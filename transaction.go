@@ -0,0 +1,140 @@
+package gorm_seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// savepointName is the savepoint created around each seeder in TransactionAll mode
+const savepointName = "gorm_seed"
+
+// TransactionMode controls how seeders are wrapped in database transactions
+type TransactionMode int
+
+const (
+	// TransactionNone runs seeders without a transaction (default)
+	TransactionNone TransactionMode = iota
+	// TransactionPerSeeder runs each seeder in its own transaction, rolling
+	// back the seeder's writes when it fails
+	TransactionPerSeeder
+	// TransactionAll runs the whole run in a single transaction that is
+	// committed only when every seeder succeeds. Each seeder runs inside a
+	// savepoint, so ContinueOnError rolls back just the failed seeder before
+	// moving on; the run as a whole is still rolled back at the end.
+	TransactionAll
+)
+
+// TransactionalSeeder is implemented by seeders that can opt out of
+// transactions, for example because they run DDL or non-transactional work
+type TransactionalSeeder interface {
+	Seeder
+	// Transactional reports whether the seeder may run inside a transaction
+	Transactional() bool
+}
+
+// isTransactional reports whether the seeder may run inside a transaction
+func isTransactional(seeder Seeder) bool {
+	if transactional, ok := seeder.(TransactionalSeeder); ok {
+		return transactional.Transactional()
+	}
+	return true
+}
+
+// withTransaction calls fn with the db handle the seeder should use,
+// wrapping it in a transaction when opts ask for one
+func withTransaction(seeder Seeder, db *gorm.DB, opts RunOptions, fn func(tx *gorm.DB) error) error {
+	switch {
+	case opts.Transaction == TransactionAll:
+		return withSavepoint(db, fn)
+	case opts.Transaction == TransactionPerSeeder && isTransactional(seeder):
+		return db.Transaction(fn)
+	default:
+		return fn(db)
+	}
+}
+
+// withSavepoint calls fn inside a savepoint of the open transaction tx,
+// rolling back to the savepoint when fn fails
+func withSavepoint(tx *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if err := tx.SavePoint(savepointName).Error; err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.RollbackTo(savepointName).Error; rollbackErr != nil {
+			return fmt.Errorf("%w (rollback to savepoint failed: %v)", err, rollbackErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// runInTransaction runs the seeders inside one transaction, committing only
+// when all of them succeed
+func runInTransaction(ctx context.Context, seeders []Seeder, db *gorm.DB, deps map[string]interface{}, opts RunOptions, history *seedHistory, dir direction) error {
+	for _, seeder := range seeders {
+		// Seeders that will be skipped, e.g. already executed ones, never run
+		// inside the transaction
+		if skipReason(seeder, opts, history, dir) != "" {
+			continue
+		}
+		if !isTransactional(seeder) {
+			return fmt.Errorf("seeder %s cannot run inside a transaction", seeder.Name())
+		}
+	}
+
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	txHistory := history.withDB(tx)
+	if err := executeSeeders(ctx, seeders, tx, deps, opts, txHistory, dir); err != nil {
+		if rollbackErr := tx.Rollback().Error; rollbackErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+		}
+		opts.events.emit(Event{Type: EventRolledBack, Rollback: dir == directionUnseed})
+		// The history rows were rolled back with the seeders; the failures
+		// are recorded again outside the transaction
+		if recordErr := history.recordFailures(txHistory); recordErr != nil {
+			return fmt.Errorf("%w (%v)", rolledBack(err), recordErr)
+		}
+		return rolledBack(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// rolledBack reports a failed run whose changes were all rolled back
+func rolledBack(err error) error {
+	var seederErrs *SeederErrors
+	if errors.As(err, &seederErrs) {
+		seederErrs.RolledBack = true
+		return seederErrs
+	}
+
+	var seederErr *SeederError
+	if errors.As(err, &seederErr) {
+		return &SeederErrors{
+			Errors:     []*SeederError{seederErr},
+			RolledBack: true,
+		}
+	}
+
+	return fmt.Errorf("%w (all changes rolled back)", err)
+}
@@ -0,0 +1,273 @@
+package gorm_seed
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// testRecord is a model written by seeders in transaction tests
+type testRecord struct {
+	ID   uint
+	Name string
+}
+
+// mockNonTransactionalSeeder is a test seeder that opts out of transactions
+type mockNonTransactionalSeeder struct {
+	mockSeeder
+}
+
+func (m *mockNonTransactionalSeeder) Transactional() bool {
+	return false
+}
+
+func setupRecordDB(t *testing.T) *gorm.DB {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&testRecord{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+func insertThenFail(name string) func(db *gorm.DB, deps map[string]interface{}) error {
+	return func(db *gorm.DB, deps map[string]interface{}) error {
+		if err := db.Create(&testRecord{Name: name}).Error; err != nil {
+			return err
+		}
+		return errors.New("failure after insert")
+	}
+}
+
+func insertRecord(name string) func(db *gorm.DB, deps map[string]interface{}) error {
+	return func(db *gorm.DB, deps map[string]interface{}) error {
+		return db.Create(&testRecord{Name: name}).Error
+	}
+}
+
+func recordNames(t *testing.T, db *gorm.DB) []string {
+	var names []string
+	if err := db.Model(&testRecord{}).Order("id").Pluck("name", &names).Error; err != nil {
+		t.Fatalf("failed to read records: %v", err)
+	}
+	return names
+}
+
+func TestRunAllWithOptions_NoTransactionKeepsPartialWrites(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{name: "001_partial", seedFunc: insertThenFail("partial")})
+
+	if err := RunAll(db, nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if names := recordNames(t, db); len(names) != 1 {
+		t.Errorf("expected partial write to remain without transactions, got %v", names)
+	}
+}
+
+func TestRunAllWithOptions_TransactionPerSeeder(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{name: "001_ok", seedFunc: insertRecord("ok")})
+	Register(&mockSeeder{name: "002_partial", seedFunc: insertThenFail("partial")})
+	Register(&mockSeeder{name: "003_after", seedFunc: insertRecord("after")})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		ContinueOnError: true,
+		Transaction:     TransactionPerSeeder,
+	})
+
+	var seederErrs *SeederErrors
+	if !errors.As(err, &seederErrs) {
+		t.Fatalf("expected SeederErrors, got %T", err)
+	}
+
+	names := recordNames(t, db)
+	if len(names) != 2 || names[0] != "ok" || names[1] != "after" {
+		t.Errorf("expected only the failed seeder's writes to be rolled back, got %v", names)
+	}
+}
+
+func TestRunAllWithOptions_TransactionOptOut(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockNonTransactionalSeeder{
+		mockSeeder: mockSeeder{name: "001_ddl", seedFunc: insertThenFail("ddl")},
+	})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Transaction: TransactionPerSeeder,
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if names := recordNames(t, db); len(names) != 1 {
+		t.Errorf("expected opted-out seeder's writes to remain, got %v", names)
+	}
+}
+
+func TestRunAllWithOptions_TransactionAllCommits(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{name: "001_first", seedFunc: insertRecord("first")})
+	Register(&mockSeeder{name: "002_second", seedFunc: insertRecord("second")})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Transaction:  TransactionAll,
+		TrackHistory: true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if names := recordNames(t, db); len(names) != 2 {
+		t.Errorf("expected 2 committed records, got %v", names)
+	}
+
+	var count int64
+	db.Model(&SeedHistory{}).Count(&count)
+	if count != 2 {
+		t.Errorf("expected 2 history records, got %d", count)
+	}
+}
+
+func TestRunAllWithOptions_TransactionAllRollsBack(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	executed := []string{}
+	Register(&mockSeeder{name: "001_first", seedFunc: insertRecord("first")})
+	Register(&mockSeeder{name: "002_partial", seedFunc: insertThenFail("partial")})
+	Register(&mockSeeder{
+		name: "003_after",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			// The failed seeder's savepoint is rolled back, so only the first row is visible
+			names := recordNames(t, db)
+			if len(names) != 1 || names[0] != "first" {
+				t.Errorf("expected only the first record inside the transaction, got %v", names)
+			}
+			executed = append(executed, "003_after")
+			return insertRecord("after")(db, deps)
+		},
+	})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		ContinueOnError: true,
+		Transaction:     TransactionAll,
+		TrackHistory:    true,
+	})
+
+	var seederErrs *SeederErrors
+	if !errors.As(err, &seederErrs) {
+		t.Fatalf("expected SeederErrors, got %T", err)
+	}
+	if !seederErrs.RolledBack {
+		t.Error("expected RolledBack to be set")
+	}
+	if len(seederErrs.Errors) != 1 || seederErrs.Errors[0].SeederName != "002_partial" {
+		t.Errorf("expected only 002_partial to fail, got %v", seederErrs.Errors)
+	}
+
+	if len(executed) != 1 {
+		t.Error("expected seeders after the failure to run with ContinueOnError")
+	}
+
+	if names := recordNames(t, db); len(names) != 0 {
+		t.Errorf("expected all records to be rolled back, got %v", names)
+	}
+
+	// Successful runs are rolled back with the run, the failure is recorded after it
+	var records []SeedHistory
+	db.Order("id").Find(&records)
+	if len(records) != 1 || records[0].SeederName != "002_partial" || records[0].Status != StatusFailed || records[0].Error == "" {
+		t.Errorf("expected only the failure of 002_partial in the history, got %+v", records)
+	}
+}
+
+func TestRunAllWithOptions_TransactionAllFailFast(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{name: "001_first", seedFunc: insertRecord("first")})
+	Register(&mockSeeder{name: "002_partial", seedFunc: insertThenFail("partial")})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Transaction:  TransactionAll,
+		TrackHistory: true,
+	})
+
+	var seederErrs *SeederErrors
+	if !errors.As(err, &seederErrs) {
+		t.Fatalf("expected SeederErrors, got %T", err)
+	}
+	if !seederErrs.RolledBack {
+		t.Error("expected RolledBack to be set")
+	}
+
+	var seederErr *SeederError
+	if !errors.As(err, &seederErr) || seederErr.SeederName != "002_partial" {
+		t.Errorf("expected SeederError for 002_partial to be unwrappable, got %v", err)
+	}
+
+	if names := recordNames(t, db); len(names) != 0 {
+		t.Errorf("expected all records to be rolled back, got %v", names)
+	}
+
+	var records []SeedHistory
+	db.Find(&records)
+	if len(records) != 1 || records[0].SeederName != "002_partial" || records[0].Status != StatusFailed {
+		t.Errorf("expected the failure of 002_partial in the history, got %+v", records)
+	}
+}
+
+func TestRunAllWithOptions_TransactionAllRejectsOptOut(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockSeeder{
+		name: "001_first",
+		seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+			t.Error("no seeder should run when one cannot run in a transaction")
+			return nil
+		},
+	})
+	Register(&mockNonTransactionalSeeder{mockSeeder: mockSeeder{name: "002_ddl"}})
+
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Transaction:  TransactionAll,
+		TrackHistory: true,
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestRunAllWithOptions_TransactionAllSkipsExecutedOptOut(t *testing.T) {
+	Clear()
+	db := setupRecordDB(t)
+
+	Register(&mockNonTransactionalSeeder{mockSeeder: mockSeeder{name: "001_ddl"}})
+	if err := RunAllWithOptions(db, nil, RunOptions{TrackHistory: true}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// 001_ddl already ran and is skipped, so it does not block the transaction
+	Register(&mockSeeder{name: "002_first", seedFunc: insertRecord("first")})
+	err := RunAllWithOptions(db, nil, RunOptions{
+		Transaction:  TransactionAll,
+		SkipExecuted: true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if names := recordNames(t, db); len(names) != 1 || names[0] != "first" {
+		t.Errorf("expected the first record, got %v", names)
+	}
+}
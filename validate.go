@@ -0,0 +1,94 @@
+package gorm_seed
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var (
+	// ErrEmptySeederName is returned for a seeder whose Name is empty
+	ErrEmptySeederName = errors.New("seeder name is empty")
+	// ErrDuplicateSeeder is returned when two seeders share a name
+	ErrDuplicateSeeder = errors.New("duplicate seeder name")
+	// ErrInvalidSeederName is returned for a name that does not follow the
+	// NNN_name or timestamp convention of gorm-seed --create
+	ErrInvalidSeederName = errors.New("invalid seeder name")
+)
+
+// seederNamePattern matches the names generated by gorm-seed --create: a
+// 3 digit sequence or 14 digit timestamp, an underscore and the name
+var seederNamePattern = regexp.MustCompile(`^(\d{3}|\d{14})_\w+$`)
+
+// ValidateName checks that name follows the naming convention of
+// gorm-seed --create, e.g. "001_users" or "20240127123045_users"
+func ValidateName(name string) error {
+	if name == "" {
+		return ErrEmptySeederName
+	}
+	if !seederNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q, expected NNN_name or YYYYMMDDHHMMSS_name", ErrInvalidSeederName, name)
+	}
+	return nil
+}
+
+// RegisterE adds a seeder to the registry after checking that its name
+// follows the naming convention and is not registered yet
+func (r *Registry) RegisterE(seeder Seeder) error {
+	if seeder == nil {
+		return fmt.Errorf("seeder is nil")
+	}
+	if err := ValidateName(seeder.Name()); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.seeders {
+		if existing.Name() == seeder.Name() {
+			return fmt.Errorf("%w: %s", ErrDuplicateSeeder, seeder.Name())
+		}
+	}
+	r.seeders = append(r.seeders, seeder)
+	return nil
+}
+
+// RegisterE adds a seeder to the default registry after validating its name
+func RegisterE(seeder Seeder) error {
+	return registry.RegisterE(seeder)
+}
+
+// Validate checks the registry for empty and duplicate seeder names. The
+// runner performs the same check before executing anything.
+func (r *Registry) Validate() error {
+	return validateSeeders(r.GetAll(), false)
+}
+
+// Validate checks the default registry for empty and duplicate seeder names
+func Validate() error {
+	return registry.Validate()
+}
+
+// validateSeeders checks name-sorted seeders for empty and duplicate names
+// and, when strict is set, for names breaking the naming convention
+func validateSeeders(seeders []Seeder, strict bool) error {
+	var errs []error
+	for i, seeder := range seeders {
+		name := seeder.Name()
+		switch {
+		case name == "":
+			errs = append(errs, ErrEmptySeederName)
+		case i > 0 && seeders[i-1].Name() == name:
+			// Report each duplicated name once
+			if i < 2 || seeders[i-2].Name() != name {
+				errs = append(errs, fmt.Errorf("%w: %s", ErrDuplicateSeeder, name))
+			}
+		case strict:
+			if err := ValidateName(name); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,112 @@
+package gorm_seed
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestValidateName(t *testing.T) {
+	valid := []string{"001_users", "20240127123045_users", "042_user_roles"}
+	for _, name := range valid {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("expected %q to be valid, got: %v", name, err)
+		}
+	}
+
+	invalid := []string{"users", "1_users", "0001_users", "001_", "001_user roles", "2024_users"}
+	for _, name := range invalid {
+		if err := ValidateName(name); !errors.Is(err, ErrInvalidSeederName) {
+			t.Errorf("expected ErrInvalidSeederName for %q, got: %v", name, err)
+		}
+	}
+
+	if err := ValidateName(""); !errors.Is(err, ErrEmptySeederName) {
+		t.Errorf("expected ErrEmptySeederName, got: %v", err)
+	}
+}
+
+func TestRegisterE(t *testing.T) {
+	reg := NewRegistry()
+
+	if err := reg.RegisterE(&mockSeeder{name: "001_users"}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := reg.RegisterE(&mockSeeder{name: "001_users"}); !errors.Is(err, ErrDuplicateSeeder) {
+		t.Errorf("expected ErrDuplicateSeeder, got: %v", err)
+	}
+	if err := reg.RegisterE(&mockSeeder{name: ""}); !errors.Is(err, ErrEmptySeederName) {
+		t.Errorf("expected ErrEmptySeederName, got: %v", err)
+	}
+	if err := reg.RegisterE(&mockSeeder{name: "users"}); !errors.Is(err, ErrInvalidSeederName) {
+		t.Errorf("expected ErrInvalidSeederName, got: %v", err)
+	}
+	if err := reg.RegisterE(nil); err == nil {
+		t.Error("expected error for nil seeder, got nil")
+	}
+
+	if reg.Count() != 1 {
+		t.Errorf("expected only the valid seeder to be registered, got %d", reg.Count())
+	}
+}
+
+func TestValidate(t *testing.T) {
+	Clear()
+	Register(&mockSeeder{name: "001_users"})
+	Register(&mockSeeder{name: "users"})
+
+	if err := Validate(); err != nil {
+		t.Errorf("expected no error without strict names, got: %v", err)
+	}
+
+	Register(&mockSeeder{name: "001_users"})
+	Register(&mockSeeder{name: "001_users"})
+	Register(&mockSeeder{name: ""})
+
+	err := Validate()
+	if !errors.Is(err, ErrDuplicateSeeder) || !errors.Is(err, ErrEmptySeederName) {
+		t.Errorf("expected duplicate and empty name errors, got: %v", err)
+	}
+	if err.Error() != "seeder name is empty\nduplicate seeder name: 001_users" {
+		t.Errorf("expected each problem to be reported once, got: %q", err.Error())
+	}
+}
+
+func TestRunAll_ValidatesBeforeExecuting(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	executed := false
+	Register(&mockSeeder{name: "001_users"})
+	Register(&mockSeeder{name: "002_products", seedFunc: func(db *gorm.DB, deps map[string]interface{}) error {
+		executed = true
+		return nil
+	}})
+	Register(&mockSeeder{name: "001_users"})
+
+	if err := RunAll(db, nil); !errors.Is(err, ErrDuplicateSeeder) {
+		t.Fatalf("expected ErrDuplicateSeeder, got: %v", err)
+	}
+	if err := RunSpecific("002_products", db, nil); !errors.Is(err, ErrDuplicateSeeder) {
+		t.Fatalf("expected ErrDuplicateSeeder, got: %v", err)
+	}
+	if executed {
+		t.Error("expected no seeder to run with an invalid registry")
+	}
+}
+
+func TestRunAllWithOptions_StrictNames(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	Register(&mockSeeder{name: "001_users"})
+	Register(&mockSeeder{name: "products"})
+
+	if err := RunAllWithOptions(db, nil, RunOptions{}); err != nil {
+		t.Fatalf("expected no error without strict names, got: %v", err)
+	}
+	if err := RunAllWithOptions(db, nil, RunOptions{StrictNames: true}); !errors.Is(err, ErrInvalidSeederName) {
+		t.Errorf("expected ErrInvalidSeederName, got: %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package gorm_seed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// VersionedSeeder is implemented by seeders whose definition changes over
+// time, like reference data. The version is stored in the history table with
+// every run.
+type VersionedSeeder interface {
+	Seeder
+	// Version returns a version or content checksum of the seeder, see Checksum
+	Version() string
+}
+
+// RunPolicy decides whether a seeder with a successful run in the history
+// runs again when SkipExecuted is set
+type RunPolicy int
+
+const (
+	// RunOnce runs the seeder until it succeeds once (default for seeders without a version)
+	RunOnce RunPolicy = iota
+	// RunAlways runs the seeder on every run
+	RunAlways
+	// RunOnChange runs the seeder again when its version differs from the
+	// version of its last successful run (default for VersionedSeeders)
+	RunOnChange
+)
+
+// String returns the name of the policy
+func (p RunPolicy) String() string {
+	switch p {
+	case RunOnce:
+		return "once"
+	case RunAlways:
+		return "always"
+	case RunOnChange:
+		return "on-change"
+	default:
+		return fmt.Sprintf("RunPolicy(%d)", int(p))
+	}
+}
+
+// PolicySeeder is implemented by seeders that choose their run policy
+type PolicySeeder interface {
+	Seeder
+	// RunPolicy returns when the seeder runs again
+	RunPolicy() RunPolicy
+}
+
+// versionOf returns the version of a seeder, or "" when it has none
+func versionOf(seeder Seeder) string {
+	if versioned, ok := seeder.(VersionedSeeder); ok {
+		return versioned.Version()
+	}
+	return ""
+}
+
+// policyOf returns the run policy of a seeder
+func policyOf(seeder Seeder) RunPolicy {
+	if policy, ok := seeder.(PolicySeeder); ok {
+		return policy.RunPolicy()
+	}
+	if _, ok := seeder.(VersionedSeeder); ok {
+		return RunOnChange
+	}
+	return RunOnce
+}
+
+// needsRun evaluates the run policy of a seeder against the history
+func needsRun(seeder Seeder, history *seedHistory) bool {
+	switch policyOf(seeder) {
+	case RunAlways:
+		return true
+	case RunOnChange:
+		version, ok := history.executedVersion(seeder.Name())
+		return !ok || version != versionOf(seeder)
+	default:
+		return !history.isExecuted(seeder.Name())
+	}
+}
+
+// Checksum returns a hex encoded SHA-256 checksum of the JSON encoding of
+// values, for use as a seeder version:
+//
+//	func (s *PermissionsSeeder) Version() string {
+//		return gorm_seed.Checksum(permissions)
+//	}
+func Checksum(values ...interface{}) string {
+	hash := sha256.New()
+	encoder := json.NewEncoder(hash)
+	for _, value := range values {
+		if err := encoder.Encode(value); err != nil {
+			// Values JSON cannot encode, like channels, fall back to their Go syntax
+			fmt.Fprintf(hash, "%#v\n", value)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
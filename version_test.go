@@ -0,0 +1,143 @@
+package gorm_seed
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// mockVersionedSeeder is a test seeder that implements VersionedSeeder and PolicySeeder
+type mockVersionedSeeder struct {
+	mockSeeder
+	version string
+	policy  *RunPolicy
+}
+
+func (m *mockVersionedSeeder) Version() string {
+	return m.version
+}
+
+func (m *mockVersionedSeeder) RunPolicy() RunPolicy {
+	if m.policy != nil {
+		return *m.policy
+	}
+	return RunOnChange
+}
+
+// countRuns returns a seed function that counts its calls in runs
+func countRuns(runs map[string]int, name string) func(db *gorm.DB, deps map[string]interface{}) error {
+	return func(db *gorm.DB, deps map[string]interface{}) error {
+		runs[name]++
+		return nil
+	}
+}
+
+func TestRunAllWithOptions_RunOnChange(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	runs := map[string]int{}
+	permissions := &mockVersionedSeeder{
+		mockSeeder: mockSeeder{name: "001_permissions", seedFunc: countRuns(runs, "001_permissions")},
+		version:    Checksum([]string{"read", "write"}),
+	}
+	Register(permissions)
+	Register(&mockSeeder{name: "002_users", seedFunc: countRuns(runs, "002_users")})
+
+	opts := RunOptions{SkipExecuted: true}
+	for i := 0; i < 2; i++ {
+		if err := RunAllWithOptions(db, nil, opts); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+	if runs["001_permissions"] != 1 || runs["002_users"] != 1 {
+		t.Fatalf("expected unchanged seeders to run once, got %v", runs)
+	}
+
+	permissions.version = Checksum([]string{"read", "write", "admin"})
+	if err := RunAllWithOptions(db, nil, opts); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if runs["001_permissions"] != 2 || runs["002_users"] != 1 {
+		t.Errorf("expected only the changed seeder to run again, got %v", runs)
+	}
+
+	var latest SeedHistory
+	db.Where("seeder_name = ?", "001_permissions").Order("id DESC").First(&latest)
+	if latest.Version != permissions.version {
+		t.Errorf("expected version %s to be recorded, got '%s'", permissions.version, latest.Version)
+	}
+}
+
+func TestRunAllWithOptions_RunPolicies(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	always, once := RunAlways, RunOnce
+	runs := map[string]int{}
+	Register(&mockVersionedSeeder{
+		mockSeeder: mockSeeder{name: "001_always", seedFunc: countRuns(runs, "001_always")},
+		policy:     &always,
+	})
+	onceSeeder := &mockVersionedSeeder{
+		mockSeeder: mockSeeder{name: "002_once", seedFunc: countRuns(runs, "002_once")},
+		version:    "v1",
+		policy:     &once,
+	}
+	Register(onceSeeder)
+
+	opts := RunOptions{SkipExecuted: true}
+	RunAllWithOptions(db, nil, opts)
+	onceSeeder.version = "v2"
+	RunAllWithOptions(db, nil, opts)
+
+	if runs["001_always"] != 2 {
+		t.Errorf("expected RunAlways seeder to run every time, got %d", runs["001_always"])
+	}
+	if runs["002_once"] != 1 {
+		t.Errorf("expected RunOnce seeder to ignore its version, got %d runs", runs["002_once"])
+	}
+}
+
+func TestRunAllWithOptions_RunOnChangeAfterFailure(t *testing.T) {
+	Clear()
+	db := setupTestDB(t)
+
+	runs := map[string]int{}
+	Register(&mockVersionedSeeder{
+		mockSeeder: mockSeeder{name: "001_countries", seedFunc: countRuns(runs, "001_countries")},
+		version:    "v1",
+	})
+
+	opts := RunOptions{SkipExecuted: true}
+	RunAllWithOptions(db, nil, opts)
+	db.Create(&SeedHistory{SeederName: "001_countries", Status: StatusFailed, Version: "v1"})
+	RunAllWithOptions(db, nil, opts)
+
+	if runs["001_countries"] != 2 {
+		t.Errorf("expected seeder whose latest run failed to run again, got %d runs", runs["001_countries"])
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	a := Checksum(map[string]int{"b": 2, "a": 1}, "x")
+	b := Checksum(map[string]int{"a": 1, "b": 2}, "x")
+	if a != b {
+		t.Errorf("expected equal checksums for equal values, got %s and %s", a, b)
+	}
+	if a == Checksum(map[string]int{"a": 1, "b": 3}, "x") {
+		t.Error("expected different checksums for different values")
+	}
+	if len(a) != 64 {
+		t.Errorf("expected hex encoded SHA-256, got %s", a)
+	}
+	if Checksum(make(chan int)) == "" {
+		t.Error("expected checksum for a value JSON cannot encode")
+	}
+}
+
+func TestRunPolicy_String(t *testing.T) {
+	if RunOnChange.String() != "on-change" || RunPolicy(7).String() != "RunPolicy(7)" {
+		t.Errorf("unexpected policy names: %s, %s", RunOnChange, RunPolicy(7))
+	}
+}